@@ -0,0 +1,144 @@
+package binding
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ErrUnknownAlias is returned when value of a field tagged with `aliases:"<name>"` is neither an alias nor a canonical
+// value of the alias map
+var ErrUnknownAlias = errors.New("unknown enum value")
+
+// aliasMap maps aliases to canonical values
+type aliasMap struct {
+	aliases   map[string]string
+	canonical map[string]bool
+}
+
+var (
+	aliasMapsMu sync.RWMutex
+	aliasMaps   = map[string]aliasMap{}
+
+	aliasCollatorMu sync.RWMutex
+	aliasCollator   func(a, b string) bool
+)
+
+// RegisterAliases registers alias map for fields of string (or string based enum) type tagged with
+// `aliases:"<name>"`. Map keys are aliases and values their canonical values, i.e. `{"USA": "US", "United States":
+// "US"}`. After conversion, non-empty field value is replaced by its canonical value. Values matching neither an alias nor a
+// canonical value fail binding with ErrUnknownAlias, use `unknown:"default"` to replace them with the `default` tag
+// value of the field or `onerror:"default"` to make the field lenient instead.
+func RegisterAliases(name string, aliases map[string]string) {
+	m := aliasMap{aliases: make(map[string]string, len(aliases)), canonical: map[string]bool{}}
+	for alias, canonical := range aliases {
+		m.aliases[alias] = canonical
+		m.canonical[canonical] = true
+	}
+	aliasMapsMu.Lock()
+	defer aliasMapsMu.Unlock()
+	aliasMaps[name] = m
+}
+
+// SetAliasCollator sets function comparing input values with aliases and canonical values which did not match exactly,
+// i.e. to match them case and accent insensitively. This package does not import collation to avoid the dependency,
+// set it from golang.org/x/text/collate instead:
+//
+//	c := collate.New(language.Und, collate.IgnoreCase, collate.IgnoreDiacritics)
+//	binding.SetAliasCollator(func(a, b string) bool { return c.CompareString(a, b) == 0 })
+//
+// Nil fn removes the collator, values are then matched case-insensitively.
+func SetAliasCollator(fn func(a, b string) bool) {
+	aliasCollatorMu.Lock()
+	defer aliasCollatorMu.Unlock()
+	aliasCollator = fn
+}
+
+// canonicalize returns canonical value of value. Aliases are matched exactly first and then with equal, which is the
+// alias collator or strings.EqualFold.
+func (m aliasMap) canonicalize(value string, equal func(a, b string) bool) (string, bool) {
+	if m.canonical[value] {
+		return value, true
+	}
+	if canonical, ok := m.aliases[value]; ok {
+		return canonical, true
+	}
+	for alias, canonical := range m.aliases {
+		if equal(alias, value) {
+			return canonical, true
+		}
+	}
+	for canonical := range m.canonical {
+		if equal(canonical, value) {
+			return canonical, true
+		}
+	}
+	return "", false
+}
+
+// applyAliases replaces bound value of string field (or pointer to or slice of strings) by its canonical value. Unknown
+// values fail unless typeField is tagged with `unknown:"default"`, then they are replaced by its `default` tag value.
+func applyAliases(name string, typeField reflect.StructField, field reflect.Value) error {
+	aliasMapsMu.RLock()
+	m, ok := aliasMaps[name]
+	aliasMapsMu.RUnlock()
+	if !ok {
+		return fmt.Errorf("aliases %q are not registered", name)
+	}
+	aliasCollatorMu.RLock()
+	equal := aliasCollator
+	aliasCollatorMu.RUnlock()
+	if equal == nil {
+		equal = strings.EqualFold
+	}
+	var fallback *string
+	switch policy := typeField.Tag.Get("unknown"); policy {
+	case "", "error":
+	case "default":
+		def := typeField.Tag.Get("default")
+		fallback = &def
+	default:
+		return fmt.Errorf("unknown enum value policy %q", policy)
+	}
+	return mapStringField(field, func(value string) (string, error) {
+		if value == "" {
+			return value, nil
+		}
+		canonical, ok := m.canonicalize(value, equal)
+		if !ok && fallback != nil {
+			return *fallback, nil
+		}
+		if !ok {
+			return "", fmt.Errorf("%w: %q", ErrUnknownAlias, value)
+		}
+		return canonical, nil
+	})
+}
+
+// mapStringField replaces bound value of string field (or pointer to or slice of strings) with result of fn
+func mapStringField(field reflect.Value, fn func(string) (string, error)) error {
+	switch field.Kind() {
+	case reflect.Ptr:
+		if field.IsNil() {
+			return nil
+		}
+		return mapStringField(field.Elem(), fn)
+	case reflect.Slice:
+		for i := 0; i < field.Len(); i++ {
+			if err := mapStringField(field.Index(i), fn); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.String:
+		value, err := fn(field.String())
+		if err != nil {
+			return err
+		}
+		field.SetString(value)
+		return nil
+	}
+	return fmt.Errorf("field of type %v is not a string", field.Type())
+}
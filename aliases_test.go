@@ -0,0 +1,107 @@
+package binding
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func init() {
+	RegisterAliases("test-countries", map[string]string{
+		"USA":           "US",
+		"United States": "US",
+		"Deutschland":   "DE",
+	})
+}
+
+func TestBindQueryParams_Aliases(t *testing.T) {
+	type query struct {
+		Country   string   `query:"country" aliases:"test-countries"`
+		Ptr       *string  `query:"ptr" aliases:"test-countries"`
+		Countries []string `query:"countries" aliases:"test-countries"`
+		Lenient   string   `query:"lenient" aliases:"test-countries" onerror:"default" default:"US"`
+		Empty     string   `query:"empty" aliases:"test-countries"`
+	}
+	var q query
+	target := "/?country=united%20states&ptr=de&countries=USA&countries=deutschland&countries=US&lenient=mars&empty="
+	if err := NewBinder().BindQueryParams(newRequest(http.MethodGet, target, "", ""), &q); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if q.Country != "US" || q.Ptr == nil || *q.Ptr != "DE" || q.Lenient != "US" || q.Empty != "" {
+		t.Errorf("got %+v", q)
+	}
+	if want := []string{"US", "DE", "US"}; !reflect.DeepEqual(q.Countries, want) {
+		t.Errorf("Countries = %v, want %v", q.Countries, want)
+	}
+}
+
+func TestBindQueryParams_UnknownAlias(t *testing.T) {
+	var q struct {
+		Country string `query:"country" aliases:"test-countries"`
+	}
+	err := NewBinder().BindQueryParams(newRequest(http.MethodGet, "/?country=mars", "", ""), &q)
+	if !errors.Is(err, ErrUnknownAlias) {
+		t.Errorf("got error %v, want ErrUnknownAlias", err)
+	}
+
+	var u struct {
+		Country string `query:"country" aliases:"test-unregistered"`
+	}
+	if err := NewBinder().BindQueryParams(newRequest(http.MethodGet, "/?country=US", "", ""), &u); err == nil {
+		t.Error("unregistered aliases: expected error")
+	}
+}
+
+func TestSetAliasCollator(t *testing.T) {
+	RegisterAliases("test-drinks", map[string]string{"coffee": "caf\u00e9"})
+	bind := func() (string, error) {
+		var q struct {
+			Drink string `query:"drink" aliases:"test-drinks"`
+		}
+		err := NewBinder().BindQueryParams(newRequest(http.MethodGet, "/?drink=CAFE", "", ""), &q)
+		return q.Drink, err
+	}
+	if _, err := bind(); !errors.Is(err, ErrUnknownAlias) {
+		t.Errorf("without collator: got error %v, want ErrUnknownAlias", err)
+	}
+
+	unaccent := strings.NewReplacer("\u00e9", "e", "\u00c9", "E")
+	SetAliasCollator(func(a, b string) bool { return strings.EqualFold(unaccent.Replace(a), unaccent.Replace(b)) })
+	defer SetAliasCollator(nil)
+	if drink, err := bind(); err != nil || drink != "caf\u00e9" {
+		t.Errorf("with collator: got %q, error %v", drink, err)
+	}
+}
+
+func TestBindQueryParams_UnknownAliasDefault(t *testing.T) {
+	type query struct {
+		Country  string   `query:"country" aliases:"test-countries" default:"OTHER" unknown:"default"`
+		Blank    string   `query:"blank" aliases:"test-countries" unknown:"default"`
+		Visited  []string `query:"visited" aliases:"test-countries" default:"OTHER" unknown:"default"`
+		Strict   string   `query:"strict" aliases:"test-countries" unknown:"error"`
+		Quantity int      `query:"quantity" unknown:"default"`
+	}
+	var q query
+	target := "/?country=Atlantis&blank=Mars&visited=usa&visited=Narnia"
+	if err := NewBinder().BindQueryParams(newRequest(http.MethodGet, target, "", ""), &q); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if q.Country != "OTHER" || q.Blank != "" || !reflect.DeepEqual(q.Visited, []string{"US", "OTHER"}) {
+		t.Errorf("got %+v", q)
+	}
+
+	if err := NewBinder().BindQueryParams(newRequest(http.MethodGet, "/?strict=Atlantis", "", ""), &query{}); !errors.Is(err, ErrUnknownAlias) {
+		t.Errorf("unknown:\"error\": got error %v, want ErrUnknownAlias", err)
+	}
+	if err := NewBinder().BindQueryParams(newRequest(http.MethodGet, "/?quantity=many", "", ""), &query{}); err == nil {
+		t.Error("conversion error is not coerced: expected error")
+	}
+	var invalid struct {
+		Country string `query:"country" aliases:"test-countries" unknown:"ignore"`
+	}
+	if err := NewBinder().BindQueryParams(newRequest(http.MethodGet, "/?country=US", "", ""), &invalid); err == nil {
+		t.Error("invalid policy: expected error")
+	}
+}
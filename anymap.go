@@ -0,0 +1,191 @@
+package binding
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// BindAnyMap binds generic map, i.e. produced by msgpack or other decoder, into struct i by field `tag` tags. Values are
+// assigned when their type is assignable to the field, numbers are converted between numeric kinds when they fit,
+// strings use the regular string conversion rules, nested maps are bound into struct and map fields and []interface{}
+// into slices. Keys are matched exactly first and then case-insensitively.
+func BindAnyMap(m map[string]interface{}, i interface{}, tag string) error {
+	val := reflect.ValueOf(i)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("binding element must be a pointer to struct, got %T", i)
+	}
+	s := &bindState{binder: defaultBinder, ctx: context.Background(), present: map[string]bool{}}
+	return bindAnyMap(s, "", m, val.Elem(), tag)
+}
+
+func bindAnyMap(s *bindState, path string, m map[string]interface{}, val reflect.Value, tag string) error {
+	typ := val.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		typeField := typ.Field(i)
+		structField := val.Field(i)
+		if !structField.CanSet() {
+			continue
+		}
+		name, _ := parseTag(typeField.Tag.Get(tag))
+		if name == "" || name == "-" {
+			// like bindData, untagged struct fields are bound from the same map
+			if name == "" && structField.Kind() == reflect.Struct {
+				if err := bindAnyMap(s, fieldPath(path, typeField), m, structField, tag); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		v, ok := m[name]
+		if !ok {
+			for k, mv := range m {
+				if strings.EqualFold(k, name) {
+					v, ok = mv, true
+					break
+				}
+			}
+		}
+		if !ok {
+			continue
+		}
+		in := fieldInput{tag: tag, path: fieldPath(path, typeField), name: name, field: typeField}
+		if err := setAnyValue(s, &in, v, structField); err != nil {
+			if be := (*BindingError)(nil); errors.As(err, &be) {
+				// error of nested field already names it
+				return err
+			}
+			return &BindingError{
+				Field:         in.path,
+				Values:        []string{fmt.Sprint(v)},
+				Message:       err.Error(),
+				InternalError: err,
+			}
+		}
+	}
+	return nil
+}
+
+// setAnyValue converts v into the field
+func setAnyValue(s *bindState, in *fieldInput, v interface{}, field reflect.Value) error {
+	if v == nil {
+		field.SetZero()
+		return nil
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Type().AssignableTo(field.Type()) {
+		field.Set(rv)
+		return nil
+	}
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+		return setAnyValue(s, in, v, field.Elem())
+	}
+	if num, ok := v.(json.Number); ok {
+		// numbers of JSON decoded with UseNumber keep their precision
+		v = num.String()
+	}
+	if str, ok := v.(string); ok && field.Kind() == reflect.Slice && in.field.Tag.Get("delim") != "" {
+		// delimited lists i.e. space separated OAuth scopes bind into slice fields with `delim` tag
+		parts := strings.Split(str, in.field.Tag.Get("delim"))
+		items := make([]interface{}, len(parts))
+		for j, part := range parts {
+			items[j] = part
+		}
+		return setAnyValue(s, in, items, field)
+	}
+	if str, ok := v.(string); ok {
+		// i.e. time.Time or numbers sent as strings
+		in.values = []string{str}
+		return setWithProperType(s, in, field.Kind(), str, field)
+	}
+
+	switch field.Kind() {
+	case reflect.Struct:
+		if nested, ok := v.(map[string]interface{}); ok {
+			return bindAnyMap(s, in.path, nested, field, in.tag)
+		}
+	case reflect.Map:
+		nested, ok := v.(map[string]interface{})
+		if !ok || field.Type().Key().Kind() != reflect.String {
+			break
+		}
+		result := reflect.MakeMapWithSize(field.Type(), len(nested))
+		for k, item := range nested {
+			elem := reflect.New(field.Type().Elem()).Elem()
+			if err := setAnyValue(s, in, item, elem); err != nil {
+				return err
+			}
+			result.SetMapIndex(reflect.ValueOf(k).Convert(field.Type().Key()), elem)
+		}
+		field.Set(result)
+		return nil
+	case reflect.Slice:
+		items, ok := v.([]interface{})
+		if !ok {
+			break
+		}
+		result := reflect.MakeSlice(field.Type(), len(items), len(items))
+		for j, item := range items {
+			if err := setAnyValue(s, in, item, result.Index(j)); err != nil {
+				return err
+			}
+		}
+		field.Set(result)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n, ok := anyInt(rv); ok && !field.OverflowInt(n) {
+			field.SetInt(n)
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n, ok := anyInt(rv); ok && n >= 0 && !field.OverflowUint(uint64(n)) {
+			field.SetUint(uint64(n))
+			return nil
+		}
+		if rv.CanUint() && !field.OverflowUint(rv.Uint()) {
+			field.SetUint(rv.Uint())
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		switch {
+		case rv.CanFloat():
+			field.SetFloat(rv.Float())
+			return nil
+		case rv.CanInt():
+			field.SetFloat(float64(rv.Int()))
+			return nil
+		case rv.CanUint():
+			field.SetFloat(float64(rv.Uint()))
+			return nil
+		}
+	}
+	return fmt.Errorf("value of type %T can not be bound into field of type %v", v, field.Type())
+}
+
+// anyInt returns integer value of numeric rv, floats only when they have no fraction
+func anyInt(rv reflect.Value) (int64, bool) {
+	switch {
+	case rv.CanInt():
+		return rv.Int(), true
+	case rv.CanUint():
+		if rv.Uint() > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(rv.Uint()), true
+	case rv.CanFloat():
+		f := rv.Float()
+		if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+			return 0, false
+		}
+		return int64(f), true
+	}
+	return 0, false
+}
@@ -0,0 +1,100 @@
+package binding
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestBindAnyMap(t *testing.T) {
+	type address struct {
+		City string `msgpack:"city"`
+	}
+	type meta struct {
+		Source string `msgpack:"source"`
+	}
+	type payload struct {
+		ID      int            `msgpack:"id"`
+		Count   uint16         `msgpack:"count"`
+		Whole   int64          `msgpack:"whole"`
+		Ratio   float32        `msgpack:"ratio"`
+		Page    int            `msgpack:"page"`
+		Name    *string        `msgpack:"Name"`
+		Note    *string        `msgpack:"note"`
+		Tags    []string       `msgpack:"tags"`
+		Scores  map[string]int `msgpack:"scores"`
+		Address address        `msgpack:"address"`
+		Skipped string         `msgpack:"-"`
+		Meta    meta
+	}
+	m := map[string]interface{}{
+		"id":      int8(7),
+		"count":   uint64(300),
+		"whole":   float64(42),
+		"ratio":   int32(2),
+		"page":    "3",
+		"name":    "bob",
+		"note":    nil,
+		"tags":    []interface{}{"a", "b"},
+		"scores":  map[string]interface{}{"x": 1.0, "y": uint8(2)},
+		"address": map[string]interface{}{"city": "NYC"},
+		"-":       "skipped",
+		"source":  "msgpack",
+	}
+	name := "bob"
+	want := payload{ID: 7, Count: 300, Whole: 42, Ratio: 2, Page: 3, Name: &name, Tags: []string{"a", "b"},
+		Scores: map[string]int{"x": 1, "y": 2}, Address: address{City: "NYC"}, Meta: meta{Source: "msgpack"}}
+	var dest payload
+	if err := BindAnyMap(m, &dest, "msgpack"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(dest, want) {
+		t.Errorf("got %+v, want %+v", dest, want)
+	}
+}
+
+func TestBindAnyMap_Errors(t *testing.T) {
+	type payload struct {
+		ID    int   `msgpack:"id"`
+		Small uint8 `msgpack:"small"`
+	}
+	testCases := []struct {
+		m     map[string]interface{}
+		field string
+	}{
+		{map[string]interface{}{"id": 1.5}, "ID"},
+		{map[string]interface{}{"id": "x"}, "ID"},
+		{map[string]interface{}{"id": true}, "ID"},
+		{map[string]interface{}{"small": 300}, "Small"},
+		{map[string]interface{}{"small": -1}, "Small"},
+	}
+	for _, tc := range testCases {
+		var dest payload
+		err := BindAnyMap(tc.m, &dest, "msgpack")
+		var be *BindingError
+		if !errors.As(err, &be) || be.Field != tc.field {
+			t.Errorf("%v: got error %v, want BindingError of %s", tc.m, err, tc.field)
+		}
+	}
+
+	var notPointer payload
+	if err := BindAnyMap(map[string]interface{}{}, notPointer, "msgpack"); err == nil {
+		t.Error("expected error for non-pointer destination")
+	}
+}
+
+func TestBindAnyMap_NestedError(t *testing.T) {
+	var dest struct {
+		Nested struct {
+			N int `msgpack:"n"`
+		} `msgpack:"nested"`
+	}
+	err := BindAnyMap(map[string]interface{}{"nested": map[string]interface{}{"n": []interface{}{1}}}, &dest, "msgpack")
+	var be *BindingError
+	if !errors.As(err, &be) || be.Field != "Nested.N" {
+		t.Fatalf("got error %v, want BindingError of Nested.N", err)
+	}
+	if errors.As(be.InternalError, new(*BindingError)) {
+		t.Errorf("error %v wraps error of nested field twice", err)
+	}
+}
@@ -0,0 +1,84 @@
+package binding
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	basesMu sync.RWMutex
+	// bases are default numeric bases of integer types
+	bases = map[reflect.Type]int{}
+)
+
+// RegisterBase registers numeric base input values of integer type typ are parsed in, i.e. for domain types always
+// sent in hex:
+//
+//	type Hex uint64
+//	binding.RegisterBase(reflect.TypeOf(Hex(0)), 16)
+//
+// Base is 2 to 36, or 0 to detect it from `0x`, `0o` and `0b` prefixes like Go literals, where leading zero alone means
+// octal (`010` is 8, `09` fails). Field `base` tag overrides the registered base.
+func RegisterBase(typ reflect.Type, base int) {
+	basesMu.Lock()
+	defer basesMu.Unlock()
+	bases[typ] = base
+}
+
+// numberBase returns base integer input of field (or its element) of type typ is parsed in, from the field `base` tag
+// (or `,base0` option of the source tag), registered for the type or DefaultBinder.AutoNumberBase
+func (s *bindState) numberBase(in *fieldInput, typ reflect.Type) (int, error) {
+	if tag, ok := in.field.Tag.Lookup("base"); ok {
+		base, err := strconv.Atoi(tag)
+		if err != nil || base != 0 && (base < 2 || base > 36) {
+			return 0, fmt.Errorf("invalid base %q of field %s", tag, in.field.Name)
+		}
+		return base, nil
+	}
+	if _, opts := parseTag(in.field.Tag.Get(in.tag)); opts.contains("base0") {
+		return 0, nil
+	}
+	basesMu.RLock()
+	base, ok := bases[typ]
+	basesMu.RUnlock()
+	if ok {
+		return base, nil
+	}
+	if s.binder.AutoNumberBase {
+		return 0, nil
+	}
+	return 10, nil
+}
+
+// setBaseNumber parses value in base into integer field. Prefix denoting the base (`0x` for 16) is accepted as well.
+func setBaseNumber(value string, base int, field reflect.Value) error {
+	if value == "" {
+		value = "0"
+	}
+	prefix := map[int]string{2: "0b", 8: "0o", 16: "0x"}[base]
+	if digits := strings.TrimPrefix(value, "-"); prefix != "" && len(digits) > 2 && strings.EqualFold(digits[:2], prefix) {
+		value = value[:len(value)-len(digits)] + digits[2:]
+	}
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, base, field.Type().Bits())
+		if err == nil {
+			field.SetInt(n)
+		}
+		return err
+	default:
+		n, err := strconv.ParseUint(value, base, field.Type().Bits())
+		if err == nil {
+			field.SetUint(n)
+		}
+		return err
+	}
+}
+
+// isIntegerKind reports whether kind is signed or unsigned integer, uintptr excluded
+func isIntegerKind(kind reflect.Kind) bool {
+	return isNumberKind(kind) && kind != reflect.Float32 && kind != reflect.Float64
+}
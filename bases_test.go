@@ -0,0 +1,121 @@
+package binding
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testHex uint64
+
+func init() {
+	RegisterBase(reflect.TypeOf(testHex(0)), 16)
+}
+
+func TestBindQueryParams_BaseTag(t *testing.T) {
+	type dest struct {
+		Color  uint32    `query:"color" base:"16"`
+		Mask   *int8     `query:"mask" base:"2"`
+		Codes  []int     `query:"codes" base:"36"`
+		ID     testHex   `query:"id"`
+		IDs    []testHex `query:"ids"`
+		Octal  testHex   `query:"octal" base:"8"`
+		Prefix int       `query:"prefix" base:"16"`
+	}
+	var d dest
+	query := "/?color=ff&mask=-101&codes=z&codes=10&id=0xbeef&ids=a&ids=FF&octal=17&prefix=-0x10"
+	if err := BindQueryParams(newRequest("GET", query, "", ""), &d); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := dest{Color: 255, Codes: []int{35, 36}, ID: 0xbeef, IDs: []testHex{10, 255}, Octal: 15, Prefix: -16}
+	if d.Mask == nil || *d.Mask != -5 {
+		t.Errorf("Mask = %v, want -5", d.Mask)
+	}
+	d.Mask = nil
+	if !reflect.DeepEqual(d, want) {
+		t.Errorf("got %+v, want %+v", d, want)
+	}
+
+	testCases := []interface{}{
+		&struct {
+			V int `query:"v" base:"1"`
+		}{},
+		&struct {
+			V int `query:"v" base:"hex"`
+		}{},
+		&struct {
+			V uint8 `query:"v" base:"16"`
+		}{},
+		&struct {
+			V int `query:"v" base:"2"`
+		}{},
+	}
+	for _, dest := range testCases {
+		if err := BindQueryParams(newRequest("GET", "/?v=102", "", ""), dest); err == nil {
+			t.Errorf("%T: expected error", dest)
+		}
+	}
+}
+
+func TestBindQueryParams_Base0(t *testing.T) {
+	type dest struct {
+		I int64  `query:"i,base0"`
+		U uint32 `query:"u" base:"0"`
+	}
+	testCases := []struct {
+		name  string
+		query string
+		wantI int64
+		wantU uint32
+	}{
+		{name: "hex", query: "i=0x1A&u=0XFF", wantI: 26, wantU: 255},
+		{name: "octal", query: "i=0o17&u=017", wantI: 15, wantU: 15},
+		{name: "binary", query: "i=0b101&u=0B11", wantI: 5, wantU: 3},
+		{name: "underscores", query: "i=-1_000&u=0x_ff_ff", wantI: -1000, wantU: 65535},
+		{name: "decimal", query: "i=42&u=0", wantI: 42, wantU: 0},
+		{name: "leading zero is octal", query: "i=010&u=0", wantI: 8, wantU: 0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var d dest
+			if err := BindQueryParams(newRequest("GET", "/?"+tc.query, "", ""), &d); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d.I != tc.wantI || d.U != tc.wantU {
+				t.Errorf("got %+v, want I=%d U=%d", d, tc.wantI, tc.wantU)
+			}
+		})
+	}
+}
+
+func TestBindQueryParams_Base0Malformed(t *testing.T) {
+	type dest struct {
+		I int `query:"i,base0"`
+	}
+	for _, value := range []string{"0xZZ", "09", "0b102", "1__000", "_1", "0x", "1e3"} {
+		t.Run(value, func(t *testing.T) {
+			var d dest
+			if err := BindQueryParams(newRequest("GET", "/?i="+value, "", ""), &d); err == nil {
+				t.Errorf("expected error, got %d", d.I)
+			}
+		})
+	}
+}
+
+func TestBindQueryParams_DecimalByDefault(t *testing.T) {
+	type dest struct {
+		I int `query:"i"`
+	}
+	var d dest
+	if err := BindQueryParams(newRequest("GET", "/?i=010", "", ""), &d); err != nil || d.I != 10 {
+		t.Errorf("got %d, %v, want 10", d.I, err)
+	}
+	if err := BindQueryParams(newRequest("GET", "/?i=0x10", "", ""), &d); err == nil {
+		t.Error("expected error for hex without base detection")
+	}
+
+	d = dest{}
+	b := NewBinder(WithAutoNumberBase(true))
+	if err := b.BindQueryParams(newRequest("GET", "/?i=0x10", "", ""), &d); err != nil || d.I != 16 {
+		t.Errorf("AutoNumberBase got %d, %v, want 16", d.I, err)
+	}
+}
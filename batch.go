@@ -0,0 +1,70 @@
+package binding
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+// BindBatch binds JSON body of bulk endpoints, object with `common` object shared by all items and `items` array, into
+// slice pointed to by items (i.e. `*[]Item` or `*[]*Item`). Every element is decoded from `common` first and then from
+// its item, so item keys override common ones. Nested objects are merged key by key the same way, arrays of the item
+// replace common ones and its explicit nulls clear pointer, slice and map fields. Neither section sets fields readonly
+// for body.
+//
+// Elements are finished like objects of Bind, computed defaults, templates, field groups and DefaultBinder.Validator
+// apply to every one of them. The same holds for elements of BindStream, BindEvents and BindCSV. Errors identify the
+// index of the item. Empty body binds nothing.
+func BindBatch(r *http.Request, items interface{}) error {
+	return defaultBinder.BindBatch(r, items)
+}
+
+// BindBatch binds JSON body with common and per-item sections. See package level BindBatch.
+func (b *DefaultBinder) BindBatch(r *http.Request, items interface{}) error {
+	slice, err := sliceDestination(items)
+	if err != nil {
+		return err
+	}
+	return b.bindElements(r, isJSONMediaType, func(s *bindState) error { return b.decodeBatch(r, s, slice) })
+}
+
+func (b *DefaultBinder) decodeBatch(r *http.Request, s *bindState, slice reflect.Value) error {
+	var batch struct {
+		Common json.RawMessage   `json:"common"`
+		Items  []json.RawMessage `json:"items"`
+	}
+	if err := b.decodeJSON(r.Body, &batch); err != nil {
+		return err
+	}
+	elemType := slice.Type().Elem()
+	isPtr := elemType.Kind() == reflect.Ptr
+	if isPtr {
+		elemType = elemType.Elem()
+	}
+	result := reflect.MakeSlice(slice.Type(), len(batch.Items), len(batch.Items))
+	for n, item := range batch.Items {
+		elem := reflect.New(elemType)
+		restore := preserveReadonly(elem.Interface())
+		if len(batch.Common) > 0 {
+			if err := b.decodeJSON(bytes.NewReader(batch.Common), elem.Interface()); err != nil {
+				return fmt.Errorf("common: %w", err)
+			}
+		}
+		if err := b.decodeJSON(bytes.NewReader(item), elem.Interface()); err != nil {
+			return fmt.Errorf("item %d: %w", n, err)
+		}
+		restore()
+		if err := b.finishElement(s, elem.Interface()); err != nil {
+			return fmt.Errorf("item %d: %w", n, err)
+		}
+		if isPtr {
+			result.Index(n).Set(elem)
+		} else {
+			result.Index(n).Set(elem.Elem())
+		}
+	}
+	slice.Set(result)
+	return nil
+}
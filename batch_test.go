@@ -0,0 +1,112 @@
+package binding
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type batchAddress struct {
+	City string `json:"city"`
+	Zip  string `json:"zip"`
+}
+
+type batchItem struct {
+	Tenant  string            `json:"tenant"`
+	Name    string            `json:"name"`
+	Tags    []string          `json:"tags"`
+	Address batchAddress      `json:"address"`
+	Note    *string           `json:"note"`
+	Labels  map[string]string `json:"labels"`
+}
+
+func TestBindBatch_OverlappingKeys(t *testing.T) {
+	body := `{
+		"common": {"tenant":"acme","tags":["import"],"address":{"city":"Oslo","zip":"0150"},"note":"n","labels":{"a":"1"}},
+		"items": [
+			{"name":"a"},
+			{"name":"b","tenant":"other","tags":["x","y"]},
+			{"name":"c","address":{"zip":"5003"},"note":null,"labels":null}
+		]
+	}`
+	var items []batchItem
+	if err := BindBatch(newRequest("POST", "/", MIMEApplicationJSON, body), &items); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	note := "n"
+	want := []batchItem{
+		{Tenant: "acme", Name: "a", Tags: []string{"import"}, Address: batchAddress{City: "Oslo", Zip: "0150"}, Note: &note,
+			Labels: map[string]string{"a": "1"}},
+		{Tenant: "other", Name: "b", Tags: []string{"x", "y"}, Address: batchAddress{City: "Oslo", Zip: "0150"}, Note: &note,
+			Labels: map[string]string{"a": "1"}},
+		{Tenant: "acme", Name: "c", Tags: []string{"import"}, Address: batchAddress{City: "Oslo", Zip: "5003"}},
+	}
+	if !reflect.DeepEqual(items, want) {
+		t.Errorf("got %+v, want %+v", items, want)
+	}
+}
+
+func TestBindBatch_ItemsDoNotShareCommonValues(t *testing.T) {
+	body := `{"common":{"tags":["import"],"labels":{"a":"1"}},"items":[{"labels":{"b":"2"}},{}]}`
+	var items []*batchItem
+	if err := BindBatch(newRequest("POST", "/", MIMEApplicationJSON, body), &items); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	items[0].Tags[0] = "changed"
+	if items[1].Tags[0] != "import" {
+		t.Errorf("second item tags = %v, want [import]", items[1].Tags)
+	}
+	if !reflect.DeepEqual(items[0].Labels, map[string]string{"a": "1", "b": "2"}) {
+		t.Errorf("first item labels = %v", items[0].Labels)
+	}
+	if !reflect.DeepEqual(items[1].Labels, map[string]string{"a": "1"}) {
+		t.Errorf("second item labels = %v", items[1].Labels)
+	}
+}
+
+func TestBindBatch_ValidatorErrorNamesItem(t *testing.T) {
+	errEmptyName := errors.New("empty name")
+	b := NewBinder(WithValidator(func(i interface{}) error {
+		if i.(*batchItem).Name == "" {
+			return errEmptyName
+		}
+		return nil
+	}))
+	body := `{"common":{"tenant":"acme"},"items":[{"name":"a"},{"tenant":"other"}]}`
+	var items []batchItem
+	err := b.BindBatch(newRequest("POST", "/", MIMEApplicationJSON, body), &items)
+	if !errors.Is(err, errEmptyName) || !strings.HasPrefix(err.Error(), "item 1:") {
+		t.Errorf("error = %v, want item 1 failing validation", err)
+	}
+}
+
+func TestBindBatch_Rejects(t *testing.T) {
+	var items []batchItem
+	if err := BindBatch(newRequest("POST", "/", MIMEApplicationJSON, `{"items":[]}`), items); err == nil {
+		t.Error("expected error for non pointer destination")
+	}
+	var unsupported *UnsupportedMediaTypeError
+	err := BindBatch(newRequest("POST", "/", MIMEApplicationXML, `<items/>`), &items)
+	if !errors.As(err, &unsupported) {
+		t.Errorf("error = %v, want UnsupportedMediaTypeError", err)
+	}
+	if err := BindBatch(newRequest("POST", "/", "application/vnd.batch+json", `{"items":[{"name":"a"}]}`), &items); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestBindBatch_ReadonlyFields(t *testing.T) {
+	type item struct {
+		Name  string `json:"name"`
+		Owner string `json:"owner" readonly:"body"`
+	}
+	body := `{"common":{"owner":"common-owner"},"items":[{"name":"a"},{"name":"b","owner":"item-owner"}]}`
+	var items []item
+	if err := BindBatch(newRequest("POST", "/", MIMEApplicationJSON, body), &items); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []item{{Name: "a"}, {Name: "b"}}; !reflect.DeepEqual(items, want) {
+		t.Errorf("got %+v, want readonly owner left unset %+v", items, want)
+	}
+}
@@ -0,0 +1,101 @@
+package binding
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+)
+
+// Numbers sent hex encoded with explicit byte order and width, i.e. `?temp=e803` binds into field
+// `Temp binding.LEUint16` tagged `query:"temp"` as 1000. Converters of these types are registered with RegisterConverter,
+// so they work in slices and behind pointers like any other converted type. Decoded input must have exactly the width
+// of the type, empty value binds as zero (pointers stay nil).
+type (
+	// LEUint16 is uint16 sent as 2 hex encoded bytes in little-endian order
+	LEUint16 uint16
+	// BEUint16 is uint16 sent as 2 hex encoded bytes in big-endian order
+	BEUint16 uint16
+	// LEUint32 is uint32 sent as 4 hex encoded bytes in little-endian order
+	LEUint32 uint32
+	// BEUint32 is uint32 sent as 4 hex encoded bytes in big-endian order
+	BEUint32 uint32
+	// LEUint64 is uint64 sent as 8 hex encoded bytes in little-endian order
+	LEUint64 uint64
+	// BEUint64 is uint64 sent as 8 hex encoded bytes in big-endian order
+	BEUint64 uint64
+	// LEInt16 is two's complement int16 sent as 2 hex encoded bytes in little-endian order
+	LEInt16 int16
+	// BEInt16 is two's complement int16 sent as 2 hex encoded bytes in big-endian order
+	BEInt16 int16
+	// LEInt32 is two's complement int32 sent as 4 hex encoded bytes in little-endian order
+	LEInt32 int32
+	// BEInt32 is two's complement int32 sent as 4 hex encoded bytes in big-endian order
+	BEInt32 int32
+	// LEInt64 is two's complement int64 sent as 8 hex encoded bytes in little-endian order
+	LEInt64 int64
+	// BEInt64 is two's complement int64 sent as 8 hex encoded bytes in big-endian order
+	BEInt64 int64
+)
+
+// binaryOrders holds byte order of hex encoded number types
+var binaryOrders = map[reflect.Type]binary.ByteOrder{
+	reflect.TypeOf(LEUint16(0)): binary.LittleEndian,
+	reflect.TypeOf(BEUint16(0)): binary.BigEndian,
+	reflect.TypeOf(LEUint32(0)): binary.LittleEndian,
+	reflect.TypeOf(BEUint32(0)): binary.BigEndian,
+	reflect.TypeOf(LEUint64(0)): binary.LittleEndian,
+	reflect.TypeOf(BEUint64(0)): binary.BigEndian,
+	reflect.TypeOf(LEInt16(0)):  binary.LittleEndian,
+	reflect.TypeOf(BEInt16(0)):  binary.BigEndian,
+	reflect.TypeOf(LEInt32(0)):  binary.LittleEndian,
+	reflect.TypeOf(BEInt32(0)):  binary.BigEndian,
+	reflect.TypeOf(LEInt64(0)):  binary.LittleEndian,
+	reflect.TypeOf(BEInt64(0)):  binary.BigEndian,
+}
+
+func init() {
+	for typ, order := range binaryOrders {
+		RegisterConverter(typ, binaryConverter(typ, order))
+	}
+}
+
+// binaryConverter returns converter hex-decoding first input value and reading it as number of type typ in byte order
+func binaryConverter(typ reflect.Type, order binary.ByteOrder) Converter {
+	size := int(typ.Size())
+	return func(values []string) (reflect.Value, error) {
+		v := reflect.New(typ).Elem()
+		value := values[0]
+		if value == "" {
+			return v, nil
+		}
+		b, err := hex.DecodeString(value)
+		if err != nil {
+			return v, fmt.Errorf("failed to hex decode binary value %q: %w", value, err)
+		}
+		if len(b) != size {
+			return v, fmt.Errorf("binary value %q has %d bytes, %v requires %d", value, len(b), typ, size)
+		}
+
+		var n uint64
+		switch size {
+		case 2:
+			n = uint64(order.Uint16(b))
+		case 4:
+			n = uint64(order.Uint32(b))
+		case 8:
+			n = order.Uint64(b)
+		}
+		switch typ.Kind() {
+		case reflect.Int16:
+			v.SetInt(int64(int16(n)))
+		case reflect.Int32:
+			v.SetInt(int64(int32(n)))
+		case reflect.Int64:
+			v.SetInt(int64(n))
+		default:
+			v.SetUint(n)
+		}
+		return v, nil
+	}
+}
@@ -0,0 +1,105 @@
+package binding
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBindQueryParams_BinaryRoundTrip(t *testing.T) {
+	testCases := []struct {
+		name  string
+		bytes []byte
+		dest  interface{}
+		want  interface{}
+	}{
+		{name: "LEUint16", bytes: binary.LittleEndian.AppendUint16(nil, 1000),
+			dest: new(LEUint16), want: LEUint16(1000)},
+		{name: "BEUint16", bytes: binary.BigEndian.AppendUint16(nil, math.MaxUint16),
+			dest: new(BEUint16), want: BEUint16(math.MaxUint16)},
+		{name: "LEUint32", bytes: binary.LittleEndian.AppendUint32(nil, 0xdeadbeef),
+			dest: new(LEUint32), want: LEUint32(0xdeadbeef)},
+		{name: "BEUint32", bytes: binary.BigEndian.AppendUint32(nil, 1),
+			dest: new(BEUint32), want: BEUint32(1)},
+		{name: "LEUint64", bytes: binary.LittleEndian.AppendUint64(nil, math.MaxUint64),
+			dest: new(LEUint64), want: LEUint64(math.MaxUint64)},
+		{name: "BEUint64", bytes: binary.BigEndian.AppendUint64(nil, 1<<63),
+			dest: new(BEUint64), want: BEUint64(1 << 63)},
+		{name: "LEInt16", bytes: binary.LittleEndian.AppendUint16(nil, uint16(0xffff)),
+			dest: new(LEInt16), want: LEInt16(-1)},
+		{name: "BEInt16", bytes: binary.BigEndian.AppendUint16(nil, 0x8000),
+			dest: new(BEInt16), want: BEInt16(math.MinInt16)},
+		{name: "LEInt32", bytes: binary.LittleEndian.AppendUint32(nil, uint32(1<<31-1)),
+			dest: new(LEInt32), want: LEInt32(math.MaxInt32)},
+		{name: "BEInt32", bytes: binary.BigEndian.AppendUint32(nil, 0xfffffffe),
+			dest: new(BEInt32), want: BEInt32(-2)},
+		{name: "LEInt64", bytes: binary.LittleEndian.AppendUint64(nil, 1<<63),
+			dest: new(LEInt64), want: LEInt64(math.MinInt64)},
+		{name: "BEInt64", bytes: binary.BigEndian.AppendUint64(nil, 1<<63-1),
+			dest: new(BEInt64), want: BEInt64(math.MaxInt64)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			typ := reflect.TypeOf(tc.dest).Elem()
+			dest := reflect.New(reflect.StructOf([]reflect.StructField{
+				{Name: "V", Type: typ, Tag: `query:"v"`},
+				{Name: "Ptr", Type: reflect.PointerTo(typ), Tag: `query:"ptr"`},
+				{Name: "All", Type: reflect.SliceOf(typ), Tag: `query:"all"`},
+			}))
+			v := hex.EncodeToString(tc.bytes)
+			target := "/?v=" + v + "&ptr=" + strings.ToUpper(v) + "&all=" + v + "&all=" + v
+			if err := BindQueryParams(newRequest(http.MethodGet, target, "", ""), dest.Interface()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := dest.Elem()
+			if got.Field(0).Interface() != tc.want || got.Field(1).Elem().Interface() != tc.want {
+				t.Errorf("got %v and %v, want %v", got.Field(0), got.Field(1).Elem(), tc.want)
+			}
+			if all := got.Field(2); all.Len() != 2 || all.Index(1).Interface() != tc.want {
+				t.Errorf("slice = %v, want two elements of %v", all, tc.want)
+			}
+		})
+	}
+}
+
+func TestBindQueryParams_BinaryErrors(t *testing.T) {
+	testCases := []struct {
+		name   string
+		target string
+		want   string
+	}{
+		{name: "too short", target: "/?u32=e803", want: "has 2 bytes, binding.LEUint32 requires 4"},
+		{name: "too long", target: "/?u16=e80300", want: "has 3 bytes, binding.BEUint16 requires 2"},
+		{name: "not hex", target: "/?u16=zz00", want: "failed to hex decode"},
+		{name: "odd length", target: "/?u16=e80", want: "failed to hex decode"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var dest struct {
+				U16 BEUint16 `query:"u16"`
+				U32 LEUint32 `query:"u32"`
+			}
+			err := BindQueryParams(newRequest(http.MethodGet, tc.target, "", ""), &dest)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("error = %v, want error containing %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestBindQueryParams_BinaryEmpty(t *testing.T) {
+	dest := struct {
+		V   LEUint16 `query:"v"`
+		Ptr *BEInt32 `query:"ptr"`
+	}{V: 7}
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?v=&ptr=", "", ""), &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dest.V != 0 || dest.Ptr != nil {
+		t.Errorf("got %v and %v, want zero and nil pointer", dest.V, dest.Ptr)
+	}
+}
@@ -4,21 +4,82 @@
 package binding
 
 import (
+	"bytes"
+	"context"
 	"encoding"
+	"encoding/base64"
 	"encoding/json"
 	"encoding/xml"
 	"errors"
 	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
 	"net/http"
+	"net/netip"
+	"net/url"
+	"os"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 )
 
+// ErrUnsupportedMediaType is matched by errors of request bodies whose content type can not be bound, see
+// UnsupportedMediaTypeError
 var ErrUnsupportedMediaType = errors.New("unsupported media type")
 
+// ErrMissingContentType is matched by UnsupportedMediaTypeError of request body sent without Content-Type header
+var ErrMissingContentType = errors.New("missing content type")
+
+// UnsupportedMediaTypeError is returned for request body of content type that can not be bound. It matches
+// ErrUnsupportedMediaType with errors.Is, and also ErrMissingContentType when request has no Content-Type.
+type UnsupportedMediaTypeError struct {
+	// ContentType is raw Content-Type header of the request, empty when it is missing
+	ContentType string
+	// notAllowed reports content type rejected by DefaultBinder.AllowedContentTypes
+	notAllowed bool
+}
+
+func (e *UnsupportedMediaTypeError) Error() string {
+	switch {
+	case e.ContentType == "":
+		return fmt.Sprintf("%v: %v", ErrUnsupportedMediaType, ErrMissingContentType)
+	case e.notAllowed:
+		return fmt.Sprintf("%v: content type %q is not allowed", ErrUnsupportedMediaType, e.ContentType)
+	}
+	return fmt.Sprintf("%v: %q", ErrUnsupportedMediaType, e.ContentType)
+}
+
+// Is matches ErrUnsupportedMediaType and, for missing Content-Type, ErrMissingContentType
+func (e *UnsupportedMediaTypeError) Is(target error) bool {
+	return target == ErrUnsupportedMediaType || target == ErrMissingContentType && e.ContentType == ""
+}
+
+// ErrBindTimeout is returned when bind call exceeds DefaultBinder.Timeout
+var ErrBindTimeout = errors.New("binding timed out")
+
+// ErrBodyTooLarge is returned when request body exceeds DefaultBinder.MaxBodySize
+var ErrBodyTooLarge = errors.New("request body too large")
+
+// defaultMemory is maximum memory multipart form parsing uses before storing file parts in temporary files, same as
+// http.Request.FormValue uses
+const defaultMemory = 32 << 20
+
+// ErrRequired is matched by errors returned for fields with `required` tag option that are missing from the input
+var ErrRequired = errors.New("required field value is missing")
+
+// ErrMissingPathParam is matched by errors returned for path params with `required` tag option that are missing or
+// empty, so handler can respond with 404. It matches ErrRequired as well.
+var ErrMissingPathParam = fmt.Errorf("missing path param: %w", ErrRequired)
+
+// ErrUnsupportedType is matched by errors returned for fields of type input values cannot be converted into, i.e.
+// channels, functions or structs without unmarshaler
+var ErrUnsupportedType = errors.New("unsupported field type")
+
 // Binder is the interface that wraps the Bind method.
 type Binder interface {
 	Bind(i interface{}, r *http.Request) error
@@ -32,6 +93,14 @@ type BindUnmarshaler interface {
 	UnmarshalParam(param string) error
 }
 
+// ContextBindUnmarshaler is the interface used to wrap the UnmarshalParamContext method. It is preferred over
+// BindUnmarshaler and is meant for implementations doing expensive work (i.e. resolving slug to ID in database) that
+// should respect request cancellation. Context is the request context, limited by field `timeout` tag when present.
+type ContextBindUnmarshaler interface {
+	// UnmarshalParamContext decodes and assigns a value from an form or query param.
+	UnmarshalParamContext(ctx context.Context, param string) error
+}
+
 // bindMultipleUnmarshaler is used by binder to unmarshal multiple values from request at once to
 // type implementing this interface. For example request could have multiple query fields `?a=1&a=2&b=test` in that case
 // for `a` following slice `["1", "2"] will be passed to unmarshaller.
@@ -39,14 +108,289 @@ type bindMultipleUnmarshaler interface {
 	UnmarshalParams(params []string) error
 }
 
-// BindPathParams binds path params to bindable object; only chi is supported
+// DefaultBinder is the default implementation of the Binder interface. Zero value binds the same way as package level
+// functions which delegate to a shared DefaultBinder instance. Create configured binder as struct literal or with
+// NewBinder and options.
+type DefaultBinder struct {
+	// AllowedContentTypes restricts media types (i.e. `application/json`) BindBody accepts. Requests with a body of other
+	// type are rejected with ErrUnsupportedMediaType before anything is bound. Empty list accepts all supported types.
+	AllowedContentTypes []string
+	// StringPreprocessor is applied to every input value bound into a string typed field (including slices of and
+	// pointers to string) i.e. `strings.TrimSpace`. It runs after field `normalize` tag. Nil leaves values unchanged.
+	StringPreprocessor func(string) string
+	// StringInterner is called with every value bound into a string typed field (including slices of and pointers to
+	// string) and the returned string is stored instead, so repeated values of hot low-cardinality fields (country
+	// codes, enums) share one copy instead of retaining an allocation per request. Nil stores values as is.
+	StringInterner func(string) string
+	// TrimSpace trims leading and trailing white space of every input value of param, query, form, header and cookie
+	// fields before conversion, so `?n= 42 ` binds into int field as 42. Field `,trim` tag option does it per field.
+	TrimSpace bool
+	// SliceErrorMode controls what happens when an element bound into slice field fails conversion. Default is to fail.
+	SliceErrorMode SliceErrorMode
+	// OnSkippedElement is called for every slice element dropped by SliceErrorSkip mode with path of the field,
+	// offending value and conversion error. Can be nil.
+	OnSkippedElement func(field string, value string, err error)
+	// BearerDelimiter splits bearer token bound by BindBearer into segments available as `bearer:"0"`, `bearer:"1"`
+	// etc. i.e. ":" for `tenant:user:sig` tokens. Empty does not split the token.
+	BearerDelimiter string
+	// ProtobufUnmarshaler decodes protobuf messages i.e. `proto.Unmarshal` wrapped to accept interface{}. When set,
+	// BindBody accepts `application/grpc-web-text` bodies which are base64-decoded before being passed to it. gRPC-Web
+	// framing is not interpreted, the unmarshaler receives decoded body as is.
+	ProtobufUnmarshaler func(data []byte, i interface{}) error
+	// CBORUnmarshaler decodes CBOR (RFC 8949) data i.e. `cbor.Unmarshal` of fxamacker/cbor. When set, BindBody accepts
+	// `application/cbor` bodies, otherwise they fail with ErrUnsupportedMediaType.
+	CBORUnmarshaler func(data []byte, i interface{}) error
+	// TagNames resolves `${NAME}` references in source tag names, i.e. `header:"${TOKEN_HEADER}"` is bound from the
+	// header named by TagNames["TOKEN_HEADER"]. Fill it from environment (or other configuration) when creating the
+	// binder. Referencing a name missing from TagNames fails the bind.
+	TagNames map[string]string
+	// IncludeCookies makes Bind bind cookies (`cookie` tag) after query params and before the body
+	IncludeCookies bool
+	// Sources lists sources Bind binds from. Sources are always bound in order path, query, header, cookie, body
+	// regardless of their order in the list. Nil binds path, query and body (and cookies with IncludeCookies).
+	Sources []Source
+	// CaseSensitive makes param, query, form, header and cookie keys to be matched only exactly. By default keys are
+	// matched case-insensitively and values of all case variants of a key are combined, exact variant first.
+	CaseSensitive bool
+	// StrictJSON rejects JSON bodies with fields unknown to the destination (error names the field) and bodies with
+	// data after the top-level value, unless ConcatenatedJSON is set
+	StrictJSON bool
+	// JSONUseNumber decodes JSON numbers into interface{} values (map[string]interface{} destinations, interface{}
+	// fields) as json.Number instead of float64, so integers beyond 2^53 keep their precision
+	JSONUseNumber bool
+	// MaxBodySize limits number of bytes read from request body by JSON, XML, form and other decoders. Larger bodies
+	// fail to bind with ErrBodyTooLarge (respond with 413). Zero means no limit.
+	MaxBodySize int64
+	// StrictJSONNull rejects explicit JSON `null` given for non-pointer fields tagged `json:"<name>,required"` with an
+	// error matching ErrRequired. By default null leaves such field with zero value.
+	StrictJSONNull bool
+	// ConcatenatedJSON makes JSON body with multiple concatenated objects (`{...}{...}`) to be decoded object by object
+	// into the destination, later objects override fields set by earlier ones. By default only the first object is
+	// decoded.
+	ConcatenatedJSON bool
+	// AppendBody makes BindBody (and Bind) with pointer to slice destination decode the body as a single element and
+	// append it to the slice instead of overwriting the slice. Repeated calls accumulate bodies, i.e. of multipart mixed
+	// stream parts. Decoding error leaves the slice unchanged.
+	AppendBody bool
+	// MultipartMemory is maximum memory multipart form parsing uses before storing file parts in temporary files.
+	// Zero uses 32MB like http.Request.FormFile.
+	MultipartMemory int64
+	// IgnoreUnknownSanitizers makes fields with `sanitize` tag naming unregistered sanitizer to be bound unsanitized
+	// instead of failing the bind
+	IgnoreUnknownSanitizers bool
+	// OnDeprecated is called when request supplies value of a field tagged with `deprecated:"true"`, with the field
+	// path, source tag (i.e. `query`) and parameter name. Request is not failed. Nil ignores deprecated fields.
+	OnDeprecated func(r *http.Request, field, source, param string)
+	// LocaleParser parses locale formatted numbers and times of fields with locale (field `locale` tag or Locale).
+	// Nil parses all values with the standard parsing.
+	LocaleParser LocaleParser
+	// Locale is default locale of all fields for LocaleParser, field `locale` tag overrides it. Empty means fields are
+	// locale specific only when they have a `locale` tag.
+	Locale string
+	// MaxJSONDepth limits nesting of objects and arrays in JSON bodies, deeper bodies fail with ErrJSONTooDeep as soon
+	// as the limit is exceeded. Zero uses DefaultMaxJSONDepth, negative value disables the limit.
+	MaxJSONDepth int
+	// UnmarshalCache caches values of fields implementing BindUnmarshaler or ContextBindUnmarshaler by their type and
+	// input value, so expensive unmarshalers (i.e. slug to ID lookups) run once per value. Nil calls unmarshalers for
+	// every value.
+	UnmarshalCache UnmarshalCache
+	// TenantBaseDomain is domain `format:"tenant"` fields take tenant from subdomains of when request has no tenant
+	// header, i.e. `example.com` binds `acme` for host `acme.example.com`. Empty binds tenant only from the header.
+	TenantBaseDomain string
+	// ThousandsSeparator is removed from values of numeric fields before parsing, i.e. `.` for `1.234.567` or `,` for
+	// `1,234,567`. Fields with locale handled by LocaleParser are not affected. Empty leaves values unchanged.
+	ThousandsSeparator string
+	// DecimalSeparator is replaced by `.` in values of numeric fields before parsing, i.e. `,` for `1.234,5` with
+	// ThousandsSeparator `.`. Fields with locale handled by LocaleParser are not affected. Empty means `.`.
+	DecimalSeparator string
+	// Now returns current time for values relative to now i.e. `format:"duration-or-time"`. Nil uses time.Now.
+	Now func() time.Time
+	// Timeout limits total time of a bind call (all its sources, body decoding and ContextBindUnmarshaler calls).
+	// Context with this deadline is passed to ContextBindUnmarshaler and body reads fail once it is exceeded. Bind
+	// calls exceeding it return ErrBindTimeout. Zero means no limit besides the request context.
+	Timeout time.Duration
+	// EmptyBoolIsTrue binds present but empty values into bool fields as true so that presence-only flags like
+	// `?verbose` or `?verbose=` work CLI-flag style. Absent keys still leave fields untouched. Default false binds
+	// empty values as false.
+	EmptyBoolIsTrue bool
+	// BoolLiterals are tokens accepted by bool fields in addition to the strconv.ParseBool ones, matched
+	// case-insensitively. Nil accepts `on`/`off` (HTML checkboxes) and `yes`/`no`, empty map accepts none.
+	BoolLiterals map[string]bool
+	// SchemaCompat makes query and form binding compatible with gorilla/schema for migrating from it. Input names are
+	// taken from `schema` tags instead of `query`/`form` ones, untagged fields bind by their Go name and fields tagged
+	// `schema:"-"` are skipped. Nested struct fields bind from keys prefixed with their name (`Address.City`).
+	SchemaCompat bool
+	// SourceTags selects struct tag input names of a source are read from instead of its own tag, i.e.
+	// {SourceQuery: "json"} binds query params by `json` tags. Options of the tag (`,omitempty`) are stripped and
+	// fields tagged `-` are skipped. SourceBody configures tag of form bodies, JSON and XML use their own tags.
+	SourceTags map[Source]string
+	// Validator is called with the bound object after every successful bind call (Bind, BindBody, BindQueryParams
+	// ...), its error is returned to the caller as is. Not called when binding fails. Nil skips validation.
+	Validator func(i interface{}) error
+	// TrustedProxies are networks of proxies whose `X-Forwarded-For` hops are skipped by `format:"client-ip"` fields.
+	// Empty list trusts no proxy, the closest hop (connection address) is the client then.
+	TrustedProxies []netip.Prefix
+	// QuerySignatureKey is HMAC-SHA256 key BindSignedQuery verifies query signatures with
+	QuerySignatureKey []byte
+	// QuerySignatureParam is name of query param carrying the signature for BindSignedQuery. Empty uses `signature`.
+	QuerySignatureParam string
+	// QuerySignatureVerifier replaces HMAC verification of BindSignedQuery, it is called with the raw query without
+	// the signature param and the signature, returned error fails the bind. Nil verifies with QuerySignatureKey.
+	QuerySignatureVerifier func(query, signature string) error
+	// QuerySignatureExpiryParam is name of signed query param holding unix time signed links expire at, i.e. `exp`.
+	// BindSignedQuery fails links past it, or without it, with ErrInvalidSignature. Empty does not check expiry.
+	QuerySignatureExpiryParam string
+	// FieldErrorInputKeys makes FieldError.Field return input key of the failed field (the wire name API consumers
+	// know) instead of its struct field path
+	FieldErrorInputKeys bool
+	// EmptySliceClears binds present keys with only empty values (`?tags=`) into slice fields as non-nil empty slices,
+	// so PATCH handlers can tell cleared list from omitted one (left nil). By default empty values are bound as
+	// elements, i.e. `[""]` for []string.
+	EmptySliceClears bool
+	// RejectConflicts makes Bind (and BindWith) fail with ErrConflictingSources when multiple sources set the same field
+	// to different values, i.e. query `?name=a` and JSON body `{"name":"b"}`. Same value from multiple sources is
+	// accepted. By default later sources silently override earlier ones.
+	RejectConflicts bool
+	// ReusableBody makes BindBody (and Bind) buffer the request body and restore r.Body with the buffered copy after
+	// decoding it, so the body can be bound again i.e. into another struct or by chained middleware. Buffering respects
+	// MaxBodySize. Body is restored also when decoding fails. By default body is streamed into decoders and consumed.
+	ReusableBody bool
+	// ErrorTranslator replaces errors of input values failing conversion into numbers, bools and other builtin kinds
+	// (raw strconv errors) i.e. with user friendly messages. It is called with the input key, source tag, the value,
+	// kind it failed to convert into and the raw error, returned error is reported instead. Nil keeps raw errors.
+	ErrorTranslator func(field, source, value string, kind reflect.Kind, err error) error
+	// MultiValueScalarPolicy selects value of single value fields receiving multiple values. Default binds the first.
+	MultiValueScalarPolicy MultiValueScalarPolicy
+	// AutoNumberBase parses integer fields like Go integer literals, base detected from `0x`, `0o` and `0b` prefixes and
+	// `_` digit separators allowed (`1_000`). Field `base` tag, `,base0` tag option or base registered with
+	// RegisterBase does it per field or type. Default parses decimal only.
+	// Beware that like in Go a leading zero alone switches to octal, `010` is 8 and `09` fails, so zero padded
+	// decimal inputs (i.e. `007`) must not be bound with detected base.
+	AutoNumberBase bool
+	// CanonicalJSON rejects JSON bodies that are not canonical with ErrNonCanonicalJSON before decoding them, so
+	// signature verified over the received bytes matches the decoded value. Canonical body has no insignificant white
+	// space, keys of every object are unique and sorted by their bytes and numbers and strings are in their shortest
+	// encoding/json form (see README).
+	CanonicalJSON bool
+	// EmptyBoolPolicies select how present but empty values bind into bool fields per source, i.e. strict path params
+	// (empty segment is a routing bug) and lenient query flags. `form` fields use SourceBody entry. Sources without
+	// entry bind empty values as true with EmptyBoolIsTrue and as false otherwise.
+	EmptyBoolPolicies map[Source]EmptyBoolPolicy
+	// JSONRoot is dot separated path of the object JSON bodies are bound from, i.e. `data` for `{"data": {...}}`
+	// envelopes. Bodies without it fail with error matching ErrJSONRootNotFound. Empty binds the whole body.
+	JSONRoot string
+	// ErrorModes select per source whether field errors fail the bind call on the first one or are collected and
+	// returned joined like by BindAll, i.e. fail-fast path params and collected form body errors. `form` fields use
+	// SourceBody entry. Sources without entry (or with ErrorModeDefault) collect errors in BindAll only.
+	ErrorModes map[Source]ErrorMode
+	// EventTypeKey is name of the discriminator member of events bound by BindEvents. Empty uses `type`.
+	EventTypeKey string
+	// SkipUnknownEvents makes BindEvents drop events with missing or unregistered discriminator instead of failing
+	// with ErrUnknownEventType
+	SkipUnknownEvents bool
+	// CSVRejectUnknownColumns makes BindCSV reject bodies with header columns not mapped to any field with error
+	// matching ErrUnknownColumn. Default ignores them.
+	CSVRejectUnknownColumns bool
+}
+
+// SliceErrorMode controls how binding into slice fields handles elements that fail conversion
+type SliceErrorMode int
+
+const (
+	// SliceErrorFail aborts binding with the conversion error of the element
+	SliceErrorFail SliceErrorMode = iota
+	// SliceErrorSkip drops elements that fail conversion and binds the rest. Dropped elements are reported to
+	// DefaultBinder.OnSkippedElement.
+	SliceErrorSkip
+)
+
+// MultiValueScalarPolicy controls which value a single value (non-slice) field receives when input has several,
+// i.e. `?sort=a&sort=b`
+type MultiValueScalarPolicy int
+
+const (
+	// MultiValueFirstWins binds the first value
+	MultiValueFirstWins MultiValueScalarPolicy = iota
+	// MultiValueLastWins binds the last value
+	MultiValueLastWins
+	// MultiValueError rejects the ambiguous input with error matching ErrMultipleValues
+	MultiValueError
+)
+
+// EmptyBoolPolicy controls how present but empty value binds into bool field, see DefaultBinder.EmptyBoolPolicies
+type EmptyBoolPolicy int
+
+const (
+	// EmptyBoolFalse binds empty value as false
+	EmptyBoolFalse EmptyBoolPolicy = iota
+	// EmptyBoolTrue binds empty value as true, like EmptyBoolIsTrue does for all sources
+	EmptyBoolTrue
+	// EmptyBoolError rejects empty value with error matching ErrEmptyBool
+	EmptyBoolError
+)
+
+// ErrorMode controls how field errors of a source are reported, see DefaultBinder.ErrorModes
+type ErrorMode int
+
+const (
+	// ErrorModeDefault fails on the first field error, except in BindAll which collects them
+	ErrorModeDefault ErrorMode = iota
+	// ErrorModeFailFast fails on the first field error, also in BindAll
+	ErrorModeFailFast
+	// ErrorModeCollect binds every field it can and returns errors of all failed fields joined with errors.Join, also
+	// in Bind, BindBody and other bind calls
+	ErrorModeCollect
+)
+
+// ErrEmptyBool is matched by errors of bool fields receiving empty value from source with EmptyBoolError policy
+var ErrEmptyBool = errors.New("empty value for bool field")
+
+// ErrMultipleValues is matched by errors of single value fields receiving multiple values with MultiValueError policy
+var ErrMultipleValues = errors.New("multiple values for single value field")
+
+// defaultBinder is used by package level binding functions
+var defaultBinder = &DefaultBinder{}
+
+// BindPathParams binds path params to bindable object. Params are taken from extractor set by SetPathParamExtractor,
+// then from chi route context and without it from stdlib http.ServeMux wildcards named by `param` tags of the object.
 func BindPathParams(r *http.Request, i interface{}) error {
+	return defaultBinder.BindPathParams(r, i)
+}
+
+// BindPathParams binds path params to bindable object. See package level BindPathParams.
+func (b *DefaultBinder) BindPathParams(r *http.Request, i interface{}) error {
+	s := b.newState(r)
+	return b.run(s, i, func() error { return b.bindPathParams(r, i, s) })
+}
+
+// BindPathParamsFor binds stdlib http.ServeMux path wildcards with given names to bindable object. Use it when
+// wildcard names can not be derived from `param` tags, i.e. binding into a map.
+func BindPathParamsFor(r *http.Request, i interface{}, names ...string) error {
+	return defaultBinder.BindPathParamsFor(r, i, names...)
+}
+
+// BindPathParamsFor binds stdlib http.ServeMux path wildcards with given names to bindable object. See package level
+// BindPathParamsFor.
+func (b *DefaultBinder) BindPathParamsFor(r *http.Request, i interface{}, names ...string) error {
+	s := b.newState(r)
+	return b.run(s, i, func() error { return bindPathValues(r, i, s, names) })
+}
+
+func (b *DefaultBinder) bindPathParams(r *http.Request, i interface{}, s *bindState) error {
+	if params := extractPathParams(r); params != nil {
+		return bindData(s, "", i, params, "param")
+	}
+
 	ctx := r.Context()
 	rctx, ok := ctx.Value(chi.RouteCtxKey).(*chi.Context)
 
 	if !ok {
-		// TODO: std lib fallback
-		return nil
+		// stdlib does not expose names of matched wildcards so look up the ones destination has fields for
+		names, err := b.paramNames(reflect.TypeOf(i), map[reflect.Type]bool{})
+		if err != nil {
+			return err
+		}
+		return bindPathValues(r, i, s, names)
 	}
 
 	keys := rctx.URLParams.Keys
@@ -55,111 +399,868 @@ func BindPathParams(r *http.Request, i interface{}) error {
 	for i, key := range keys {
 		params[key] = []string{values[i]}
 	}
-	if err := bindData(i, params, "param"); err != nil {
+	if err := bindData(s, "", i, params, "param"); err != nil {
 		return err
 	}
 	return nil
 }
 
+// bindPathValues binds stdlib path wildcards with given names. Wildcard with empty value is treated as absent as
+// http.Request.PathValue does not distinguish it from unmatched one.
+func bindPathValues(r *http.Request, i interface{}, s *bindState, names []string) error {
+	params := map[string][]string{}
+	for _, name := range names {
+		if v := r.PathValue(name); v != "" {
+			params[name] = []string{v}
+		}
+	}
+	return bindData(s, "", i, params, "param")
+}
+
+// paramNames returns names of all `param` tags (or tags configured for path params) of struct type t, including nested structs bound by bindData
+func (b *DefaultBinder) paramNames(t reflect.Type, visited map[reflect.Type]bool) ([]string, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || visited[t] {
+		return nil, nil
+	}
+	visited[t] = true
+
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _ := parseTag(field.Tag.Get(b.nameTag("param")))
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			nested, err := b.paramNames(field.Type, visited)
+			if err != nil {
+				return nil, err
+			}
+			names = append(names, nested...)
+			continue
+		}
+		name, err := b.substituteNames(name)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
 // BindQueryParams binds query params to bindable object
 func BindQueryParams(r *http.Request, i interface{}) error {
-	if err := bindData(i, r.URL.Query(), "query"); err != nil {
+	return defaultBinder.BindQueryParams(r, i)
+}
+
+// BindQueryParams binds query params to bindable object
+func (b *DefaultBinder) BindQueryParams(r *http.Request, i interface{}) error {
+	s := b.newState(r)
+	return b.run(s, i, func() error { return b.bindQueryParams(r, i, s) })
+}
+
+func (b *DefaultBinder) bindQueryParams(r *http.Request, i interface{}, s *bindState) error {
+	if err := bindData(s, "", i, r.URL.Query(), "query"); err != nil {
 		return err
 	}
 	return nil
 }
 
+// QueryPair is a single query parameter key and value
+type QueryPair struct {
+	Key   string
+	Value string
+}
+
+// BindQueryPairs returns all query parameters in the order they appear in the URL, including repeated keys. Unlike map
+// based binding this preserves the query exactly, i.e. for forwarding it. Keys and values are unescaped the same way
+// url.ParseQuery does and malformed escapes or semicolons return an error.
+func BindQueryPairs(r *http.Request) ([]QueryPair, error) {
+	var pairs []QueryPair
+	query := r.URL.RawQuery
+	for query != "" {
+		var segment string
+		segment, query, _ = strings.Cut(query, "&")
+		if segment == "" {
+			continue
+		}
+		if strings.Contains(segment, ";") {
+			return pairs, errors.New("invalid semicolon separator in query")
+		}
+		k, v, _ := strings.Cut(segment, "=")
+		key, err := url.QueryUnescape(k)
+		if err != nil {
+			return pairs, err
+		}
+		value, err := url.QueryUnescape(v)
+		if err != nil {
+			return pairs, err
+		}
+		pairs = append(pairs, QueryPair{Key: key, Value: value})
+	}
+	return pairs, nil
+}
+
 // BindBody binds request body contents to bindable object
 // NB: then binding forms take note that this implementation uses standard library form parsing
 // which parses form data from BOTH URL and BODY if content type is not MIMEMultipartForm
 // See non-MIMEMultipartForm: https://golang.org/pkg/net/http/#Request.ParseForm
 // See MIMEMultipartForm: https://golang.org/pkg/net/http/#Request.ParseMultipartForm
 func BindBody(r *http.Request, i interface{}) (err error) {
+	return defaultBinder.BindBody(r, i)
+}
+
+// BindBody binds request body contents to bindable object. See package level BindBody.
+func (b *DefaultBinder) BindBody(r *http.Request, i interface{}) (err error) {
+	s := b.newState(r)
+	return b.run(s, i, func() error { return b.bindBody(r, i, s) })
+}
+
+func (b *DefaultBinder) bindBody(r *http.Request, i interface{}, s *bindState) (err error) {
 	if r.ContentLength == 0 {
 		return
 	}
+	if b.AppendBody {
+		if dst := reflect.ValueOf(i); dst.Kind() == reflect.Pointer && dst.Elem().Kind() == reflect.Slice {
+			// body is decoded as single element of the slice and appended to elements bound by previous calls
+			elem := reflect.New(dst.Elem().Type().Elem())
+			if err := b.decodeBody(r, elem.Interface(), s); err != nil {
+				return err
+			}
+			dst.Elem().Set(reflect.Append(dst.Elem(), elem.Elem()))
+			return nil
+		}
+	}
+	if err := b.decodeBody(r, i, s); err != nil {
+		return err
+	}
+	return s.checkBodyConflicts()
+}
+
+// decodeBody decodes request body into i according to its content type
+func (b *DefaultBinder) decodeBody(r *http.Request, i interface{}, s *bindState) (err error) {
+	if err := b.checkContentType(r); err != nil {
+		return err
+	}
+	raw, err := rawBodyTargets(i, false)
+	if err != nil {
+		return err
+	}
+	if len(raw) == 0 && !b.ReusableBody {
+		return b.readBody(r, s, func() error { return b.decodeBodyAs(r, i, s) })
+	}
+
+	// destination wants raw body as well (or body is reusable), decode buffered copy and leave another one for later
+	// reads
+	body, err := b.bufferBody(r, s)
+	if err != nil {
+		return err
+	}
+	err = b.decodeBodyAs(r, i, s)
+	// body is left for later reads even when decoding failed part way through it
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	setRawBody(raw, body)
+	return nil
+}
+
+// readBody runs fn reading request body limited by MaxBodySize and Timeout
+func (b *DefaultBinder) readBody(r *http.Request, s *bindState, fn func() error) (err error) {
+	if b.MaxBodySize > 0 {
+		if r.ContentLength > b.MaxBodySize {
+			return fmt.Errorf("%w: content length %d exceeds limit of %d bytes", ErrBodyTooLarge, r.ContentLength, b.MaxBodySize)
+		}
+		body := r.Body
+		r.Body = http.MaxBytesReader(nil, body, b.MaxBodySize)
+		defer func() {
+			r.Body = body
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				err = fmt.Errorf("%w: body exceeds limit of %d bytes", ErrBodyTooLarge, tooLarge.Limit)
+			}
+		}()
+	}
+	if s.cancel != nil {
+		body := r.Body
+		r.Body = deadlineReader{s: s, ReadCloser: body}
+		defer func() { r.Body = body }()
+	}
+	return fn()
+}
 
+// decodeBodyAs decodes request body with decoder selected by its content type
+func (b *DefaultBinder) decodeBodyAs(r *http.Request, i interface{}, s *bindState) error {
 	cType := r.Header.Get(HeaderContentType)
-	switch {
-	case strings.HasPrefix(cType, MIMEApplicationJSON):
-		if err = json.NewDecoder(r.Body).Decode(i); err != nil {
+	decode, ok := lookupDecoder(cType)
+	if !ok {
+		return &UnsupportedMediaTypeError{ContentType: cType}
+	}
+	return decode(b, r, i, s)
+}
+
+func (b *DefaultBinder) decodeJSONBody(r *http.Request, i interface{}, _ *bindState) error {
+	defer preserveReadonly(i)()
+	if b.StrictJSONNull || b.CanonicalJSON || b.JSONRoot != "" {
+		body, err := io.ReadAll(b.limitJSONDepth(r.Body))
+		if err != nil {
 			return err
 		}
-	case strings.HasPrefix(cType, MIMEApplicationXML), strings.HasPrefix(cType, MIMETextXML):
-		if err = xml.NewDecoder(r.Body).Decode(i); err != nil {
-			if ute, ok := err.(*xml.UnsupportedTypeError); ok {
-				return errors.Join(fmt.Errorf("unsupported type error: type=%v", ute.Type), err)
-			} else if se, ok := err.(*xml.SyntaxError); ok {
-				return errors.Join(fmt.Errorf("syntax error: line=%v, error=%v", se.Line, se.Error()), err)
+		if b.CanonicalJSON {
+			if err = checkCanonicalJSON(body); err != nil {
+				return err
+			}
+		}
+		if b.JSONRoot != "" {
+			if body, err = jsonRoot(body, b.JSONRoot); err != nil {
+				return err
 			}
-			return err
 		}
-	case strings.HasPrefix(cType, MIMEApplicationForm), strings.HasPrefix(cType, MIMEMultipartForm):
-		if err := r.ParseForm(); err != nil {
+		if err = b.decodeJSON(bytes.NewReader(body), i); err != nil {
 			return err
 		}
-		params := r.PostForm
-		if err = bindData(i, params, "form"); err != nil {
+		if b.StrictJSONNull {
+			if err = checkJSONNullsStream(body, reflect.TypeOf(i)); err != nil {
+				return err
+			}
+		}
+	} else if err := b.decodeJSON(r.Body, i); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (b *DefaultBinder) decodeXMLBody(r *http.Request, i interface{}, _ *bindState) error {
+	defer preserveReadonly(i)()
+	if err := xml.NewDecoder(r.Body).Decode(i); err != nil {
+		if ute, ok := err.(*xml.UnsupportedTypeError); ok {
+			return errors.Join(fmt.Errorf("unsupported type error: type=%v", ute.Type), err)
+		} else if se, ok := err.(*xml.SyntaxError); ok {
+			return errors.Join(fmt.Errorf("syntax error: line=%v, error=%v", se.Line, se.Error()), err)
+		}
+		return err
+	}
+	return nil
+}
+
+func (b *DefaultBinder) decodeGRPCWebTextBody(r *http.Request, i interface{}, _ *bindState) error {
+	if b.ProtobufUnmarshaler == nil {
+		return &UnsupportedMediaTypeError{ContentType: r.Header.Get(HeaderContentType)}
+	}
+	body, err := io.ReadAll(base64.NewDecoder(base64.StdEncoding, r.Body))
+	if err != nil {
+		return fmt.Errorf("failed to base64 decode %s body: %w", MIMEApplicationGRPCWebText, err)
+	}
+	return b.ProtobufUnmarshaler(body, i)
+}
+
+func (b *DefaultBinder) decodeCBORBody(r *http.Request, i interface{}, _ *bindState) error {
+	if b.CBORUnmarshaler == nil {
+		return &UnsupportedMediaTypeError{ContentType: r.Header.Get(HeaderContentType)}
+	}
+	defer preserveReadonly(i)()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	return b.CBORUnmarshaler(body, i)
+}
+
+func (b *DefaultBinder) decodeJSON(r io.Reader, i interface{}) error {
+	decoder := json.NewDecoder(b.limitJSONDepth(r))
+	if b.StrictJSON {
+		decoder.DisallowUnknownFields()
+	}
+	if b.JSONUseNumber {
+		decoder.UseNumber()
+	}
+	if err := decoder.Decode(i); err != nil {
+		return err
+	}
+	if !b.ConcatenatedJSON {
+		if b.StrictJSON {
+			// anything but whitespace after the top-level value is an error i.e. `{"a":1}{"b":2}`
+			if _, err := decoder.Token(); err != io.EOF {
+				return errors.New("json: unexpected data after top-level value")
+			}
+		}
+		return nil
+	}
+	// objects following the first one are merged into the destination, later objects override earlier fields
+	for {
+		if err := decoder.Decode(i); err != nil {
+			if err == io.EOF {
+				return nil
+			}
 			return err
 		}
-	default:
-		return ErrUnsupportedMediaType
 	}
+}
+
+// BindForm binds POST form body (`r.PostForm`) to bindable object with `form` tag regardless of request content type.
+// Unlike http.Request.Form, URL query values are never bound. Use BindMultipartForm for `multipart/form-data` bodies.
+func BindForm(r *http.Request, i interface{}) error {
+	return defaultBinder.BindForm(r, i)
+}
+
+// BindForm binds POST form body to bindable object. See package level BindForm.
+func (b *DefaultBinder) BindForm(r *http.Request, i interface{}) error {
+	s := b.newState(r)
+	return b.run(s, i, func() error {
+		return b.readBody(r, s, func() error {
+			if err := parsePostForm(r); err != nil {
+				return err
+			}
+			return b.bindForm(r, i, s, false)
+		})
+	})
+}
+
+// parsePostForm parses URL encoded body of request with other than form content type into r.PostForm, as
+// http.Request.ParseForm only reads bodies of `application/x-www-form-urlencoded` requests. Multipart bodies are left to
+// BindMultipartForm.
+func parsePostForm(r *http.Request) error {
+	if r.PostForm != nil || r.Body == nil || r.Body == http.NoBody {
+		return nil
+	}
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get(HeaderContentType))
+	if mediaType == MIMEApplicationForm || strings.HasPrefix(mediaType, "multipart/") {
+		return nil
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return err
+	}
+	r.PostForm = values
 	return nil
 }
 
+// BindMultipartForm binds text values and uploaded files of `multipart/form-data` body to bindable object with `form`
+// tag. URL query values are never bound.
+func BindMultipartForm(r *http.Request, i interface{}) error {
+	return defaultBinder.BindMultipartForm(r, i)
+}
+
+// BindMultipartForm binds multipart form body to bindable object. See package level BindMultipartForm.
+func (b *DefaultBinder) BindMultipartForm(r *http.Request, i interface{}) error {
+	s := b.newState(r)
+	return b.run(s, i, func() error {
+		return b.readBody(r, s, func() error { return b.bindForm(r, i, s, true) })
+	})
+}
+
+// bindForm parses form body and binds its values (and uploaded files of multipart form)
+func (b *DefaultBinder) bindForm(r *http.Request, i interface{}, s *bindState, isMultipart bool) error {
+	if isMultipart {
+		// ParseForm does not read multipart bodies, ParseMultipartForm adds all (also repeated) text parts to PostForm
+		if err := r.ParseMultipartForm(b.multipartMemory()); err != nil {
+			return err
+		}
+		s.files = r.MultipartForm.File
+		defer func() { s.files = nil }()
+	} else if err := r.ParseForm(); err != nil {
+		return err
+	}
+	return bindData(s, "", i, r.PostForm, "form")
+}
+
+// checkContentType returns error when request has a body with media type not listed in AllowedContentTypes
+func (b *DefaultBinder) checkContentType(r *http.Request) error {
+	if len(b.AllowedContentTypes) == 0 || r.ContentLength == 0 {
+		return nil
+	}
+	cType := r.Header.Get(HeaderContentType)
+	mediaType, _, err := mime.ParseMediaType(cType)
+	if err == nil {
+		for _, allowed := range b.AllowedContentTypes {
+			if strings.EqualFold(mediaType, allowed) {
+				return nil
+			}
+		}
+	}
+	return &UnsupportedMediaTypeError{ContentType: cType, notAllowed: true}
+}
+
 // BindHeaders binds HTTP headers to a bindable object
 func BindHeaders(r *http.Request, i interface{}) error {
-	if err := bindData(i, r.Header, "header"); err != nil {
+	return defaultBinder.BindHeaders(r, i)
+}
+
+// BindHeaders binds HTTP headers to a bindable object
+func (b *DefaultBinder) BindHeaders(r *http.Request, i interface{}) error {
+	s := b.newState(r)
+	return b.run(s, i, func() error { return b.bindHeaders(r, i, s) })
+}
+
+func (b *DefaultBinder) bindHeaders(r *http.Request, i interface{}, s *bindState) error {
+	if err := bindData(s, "", i, r.Header, "header"); err != nil {
 		return err
 	}
 	return nil
 }
 
+// BindCookies binds request cookies to a bindable object. Multiple cookies with the same name bind into slices in
+// order of the Cookie header.
+func BindCookies(r *http.Request, i interface{}) error {
+	return defaultBinder.BindCookies(r, i)
+}
+
+// BindCookies binds request cookies to a bindable object. See package level BindCookies.
+func (b *DefaultBinder) BindCookies(r *http.Request, i interface{}) error {
+	s := b.newState(r)
+	return b.run(s, i, func() error { return b.bindCookies(r, i, s) })
+}
+
+func (b *DefaultBinder) bindCookies(r *http.Request, i interface{}, s *bindState) error {
+	params := map[string][]string{}
+	for _, cookie := range r.Cookies() {
+		params[cookie.Name] = append(params[cookie.Name], cookie.Value)
+	}
+	return bindData(s, "", i, params, "cookie")
+}
+
+// BindBearer binds bearer token from Authorization header to bindable object. Whole token binds into fields tagged
+// `bearer:"token"`, when DefaultBinder.BearerDelimiter is set token segments bind into `bearer:"0"`, `bearer:"1"` etc.
+// Binds nothing when request has no bearer token.
+func BindBearer(r *http.Request, i interface{}) error {
+	return defaultBinder.BindBearer(r, i)
+}
+
+// BindBearer binds bearer token from Authorization header to bindable object. See package level BindBearer.
+func (b *DefaultBinder) BindBearer(r *http.Request, i interface{}) error {
+	s := b.newState(r)
+	return b.run(s, i, func() error { return b.bindBearer(r, i, s) })
+}
+
+func (b *DefaultBinder) bindBearer(r *http.Request, i interface{}, s *bindState) error {
+	scheme, token, ok := strings.Cut(r.Header.Get(HeaderAuthorization), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return nil
+	}
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil
+	}
+
+	params := map[string][]string{"token": {token}}
+	if b.BearerDelimiter != "" {
+		for n, segment := range strings.Split(token, b.BearerDelimiter) {
+			params[strconv.Itoa(n)] = []string{segment}
+		}
+	}
+	return bindData(s, "", i, params, "bearer")
+}
+
 // Bind implements the `Binder#Bind` function.
-// Binding is done in following order: 1) path params; 2) query params; 3) request body. Each step COULD override previous
-// step binded values. For single source binding use their own methods BindBody, BindQueryParams, BindPathParams.
+// Binding is done in following order: 1) path params; 2) query params; 3) cookies, only when
+// DefaultBinder.IncludeCookies is set; 4) request body. DefaultBinder.Sources can change which of them (and headers,
+// bound after query params) take part. Each step COULD override previous step binded values. For single source binding use their own methods BindBody, BindQueryParams, BindPathParams.
 func Bind(r *http.Request, i interface{}) (err error) {
-	if err := BindPathParams(r, i); err != nil {
+	return defaultBinder.Bind(i, r)
+}
+
+// Bind implements the `Binder#Bind` function. See package level Bind.
+func (b *DefaultBinder) Bind(i interface{}, r *http.Request) (err error) {
+	s := b.newState(r)
+	s.groups = true
+	return b.run(s, i, func() error { return b.bind(r, i, s) })
+}
+
+// BindAll binds the same way as Bind but does not stop on the first field that fails to bind. Errors of all fields
+// from path, query, cookie and form sources are returned joined with errors.Join, each as FieldError wrapping
+// *BindingError identifying the input key, source and struct field, except sources DefaultBinder.ErrorModes makes fail
+// fast. JSON/XML body decoding fails as a whole and its error is joined after them.
+func BindAll(r *http.Request, i interface{}) error {
+	return defaultBinder.BindAll(r, i)
+}
+
+// BindAll binds the same way as Bind but collects errors of all fields. See package level BindAll.
+func (b *DefaultBinder) BindAll(r *http.Request, i interface{}) error {
+	s := b.newState(r)
+	s.collect = true
+	s.groups = true
+	return b.run(s, i, func() error { return b.bind(r, i, s) })
+}
+
+// BindWithRaw binds the same way as Bind and additionally returns raw input values of every bound field keyed by
+// field path (i.e. `Address.City`). Multiple values of a field are joined with comma. Fields decoded by JSON/XML body
+// decoders are not recorded as their raw values are not available.
+func BindWithRaw(r *http.Request, i interface{}) (map[string]string, error) {
+	return defaultBinder.BindWithRaw(r, i)
+}
+
+// BindWithRaw binds the same way as Bind and additionally returns raw input values. See package level BindWithRaw.
+func (b *DefaultBinder) BindWithRaw(r *http.Request, i interface{}) (map[string]string, error) {
+	s := b.newState(r)
+	s.raw = map[string]string{}
+	s.groups = true
+	err := b.run(s, i, func() error { return b.bind(r, i, s) })
+	return s.raw, err
+}
+
+func (b *DefaultBinder) bind(r *http.Request, i interface{}, s *bindState) error {
+	// reject disallowed body up front so nothing is bound from path or query either
+	if err := b.checkContentType(r); err != nil {
+		return err
+	}
+	if err := b.bindSource(s, SourcePath, func() error { return b.bindPathParams(r, i, s) }); err != nil {
+		return err
+	}
+	if err := b.bindSource(s, SourceQuery, func() error { return b.bindQueryParams(r, i, s) }); err != nil {
+		return err
+	}
+	if err := b.bindSource(s, SourceHeader, func() error { return b.bindHeaders(r, i, s) }); err != nil {
+		return err
+	}
+	if err := b.bindSource(s, SourceCookie, func() error { return b.bindCookies(r, i, s) }); err != nil {
+		return err
+	}
+	if !b.includes(SourceBody) {
+		return nil
+	}
+	return b.bindBody(r, i, s)
+}
+
+// BindWith binds data from given sources in given order, data of later sources overriding data of earlier ones, i.e.
+// BindWith(r, &payload, SourceBody, SourceQuery) makes query params win over the body. Sources not listed only bind
+// fields overridden to them by request context (see WithSourceOverrides). Without sources it binds the same way as Bind.
+func BindWith(r *http.Request, i interface{}, sources ...Source) error {
+	return defaultBinder.BindWith(i, r, sources...)
+}
+
+// BindWith binds data from given sources in given order. See package level BindWith.
+func (b *DefaultBinder) BindWith(i interface{}, r *http.Request, sources ...Source) error {
+	if len(sources) == 0 {
+		return b.Bind(i, r)
+	}
+	s := b.newState(r)
+	s.groups = true
+	return b.run(s, i, func() error { return b.bindWith(r, i, s, sources) })
+}
+
+func (b *DefaultBinder) bindWith(r *http.Request, i interface{}, s *bindState, sources []Source) error {
+	listed := map[Source]bool{}
+	for _, source := range sources {
+		if b.sourceBinder(r, i, s, source) == nil {
+			return fmt.Errorf("unknown source %q", source)
+		}
+		if source == SourceBody {
+			if err := b.checkContentType(r); err != nil {
+				return err
+			}
+		}
+		listed[source] = true
+	}
+	for _, source := range sources {
+		if err := b.sourceBinder(r, i, s, source)(); err != nil {
+			return err
+		}
+	}
+	for _, source := range []Source{SourcePath, SourceQuery, SourceHeader, SourceCookie} {
+		if listed[source] || !s.overridesTo(source) {
+			continue
+		}
+		s.overriddenOnly = true
+		err := b.sourceBinder(r, i, s, source)()
+		s.overriddenOnly = false
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sourceBinder returns function binding data of source, nil for unknown source
+func (b *DefaultBinder) sourceBinder(r *http.Request, i interface{}, s *bindState, source Source) func() error {
+	switch source {
+	case SourcePath:
+		return func() error { return b.bindPathParams(r, i, s) }
+	case SourceQuery:
+		return func() error { return b.bindQueryParams(r, i, s) }
+	case SourceHeader:
+		return func() error { return b.bindHeaders(r, i, s) }
+	case SourceCookie:
+		return func() error { return b.bindCookies(r, i, s) }
+	case SourceBody:
+		return func() error { return b.bindBody(r, i, s) }
+	}
+	return nil
+}
+
+// bindSource binds source included in Bind, sources excluded from Bind only bind fields overridden to them by request
+// context (see WithSourceOverrides)
+func (b *DefaultBinder) bindSource(s *bindState, source Source, bind func() error) error {
+	if b.includes(source) {
+		return bind()
+	}
+	if !s.overridesTo(source) {
+		return nil
+	}
+	s.overriddenOnly = true
+	defer func() { s.overriddenOnly = false }()
+	return bind()
+}
+
+func (b *DefaultBinder) multipartMemory() int64 {
+	if b.MultipartMemory > 0 {
+		return b.MultipartMemory
+	}
+	return defaultMemory
+}
+
+func (b *DefaultBinder) now() time.Time {
+	if b.Now != nil {
+		return b.Now()
+	}
+	return time.Now()
+}
+
+// bindState holds state shared by all bindData calls of single bind operation
+type bindState struct {
+	binder *DefaultBinder
+	// req is the request being bound
+	req *http.Request
+	// ctx is the request context, passed to ContextBindUnmarshaler implementations
+	ctx context.Context
+	// opCtx is context limited by DefaultBinder.Timeout and cancel releases it, both are nil when there is no timeout
+	opCtx  context.Context
+	cancel context.CancelFunc
+	// raw records raw input values by field path when not nil
+	raw map[string]string
+	// present records paths of fields input values were found for
+	present map[string]bool
+	// files are uploaded files of multipart form being bound
+	files map[string][]*multipart.FileHeader
+	// overrides are field sources overridden by request context, see WithSourceOverrides
+	overrides map[string]Source
+	// overriddenOnly limits binding to fields with overridden source while Bind binds a source it does not include
+	overriddenOnly bool
+	// collect makes field errors to be recorded in errs instead of failing the bind
+	collect bool
+	errs    []error
+	// bound are values fields were set to by sources, recorded with DefaultBinder.RejectConflicts
+	bound map[string]boundValue
+	// groups makes field groups to be checked after binding, set by calls binding objects from all their sources as
+	// single source calls can not tell group members bound by other sources
+	groups bool
+	// keyPrefix is input key of namespaced struct being bound i.e. `address.`, prepended to keys of its field errors
+	keyPrefix string
+}
+
+func (b *DefaultBinder) newState(r *http.Request) *bindState {
+	s := &bindState{binder: b, req: r, ctx: r.Context(), present: map[string]bool{}, overrides: sourceOverridesFrom(r.Context())}
+	if b.Timeout > 0 {
+		s.opCtx, s.cancel = context.WithTimeout(s.ctx, b.Timeout)
+		s.ctx = s.opCtx
+	}
+	return s
+}
+
+// resetElement forgets fields bound into previous element of bind calls binding multiple objects (i.e. CSV records),
+// so presence and conflicts of every element are tracked on their own
+func (s *bindState) resetElement() {
+	s.present = map[string]bool{}
+	s.bound = nil
+	if s.raw != nil {
+		s.raw = map[string]string{}
+	}
+}
+
+// fieldError returns err of the field found under key, or records it and returns nil when state collects errors of
+// all fields. Timeouts are never collected.
+func (s *bindState) fieldError(source, key, path string, values []string, err error) error {
+	if errors.Is(err, ErrBindTimeout) || s.timedOut() {
 		return err
 	}
+	key = s.keyPrefix + key
+	if !s.collects(source) {
+		return s.newFieldError(source, key, path, values, err)
+	}
+	be, ok := err.(*BindingError)
+	if !ok {
+		be = &BindingError{Field: key, Values: values, Message: err.Error(), InternalError: err}
+	}
+	if be.Source == "" {
+		be.Source = source
+	}
+	if be.StructField == "" {
+		be.StructField = path
+	}
+	s.errs = append(s.errs, s.newFieldError(be.Source, key, be.StructField, be.Values, be))
+	return nil
+}
+
+// collects reports whether field errors of source tag are recorded, per DefaultBinder.ErrorModes or BindAll
+func (s *bindState) collects(tag string) bool {
+	source := Source(tag)
+	if tag == "form" {
+		source = SourceBody
+	}
+	switch s.binder.ErrorModes[source] {
+	case ErrorModeFailFast:
+		return false
+	case ErrorModeCollect:
+		return true
+	}
+	return s.collect
+}
 
-	if err := BindQueryParams(r, i); err != nil {
+// newFieldError returns err wrapped as FieldError, errors of nested fields already wrapped are returned as is
+func (s *bindState) newFieldError(source, key, path string, values []string, err error) error {
+	if fe := (FieldError)(nil); errors.As(err, &fe) {
 		return err
 	}
-	return BindBody(r, i)
+	field := path
+	if s.binder.FieldErrorInputKeys || field == "" {
+		field = key
+	}
+	return &fieldError{field: field, source: source, values: values, err: err}
+}
+
+// run executes bind operation bindFn with the state and post bind steps
+func (b *DefaultBinder) run(s *bindState, i interface{}, bindFn func() error) error {
+	if s.cancel != nil {
+		defer s.cancel()
+	}
+	err := bindFn()
+	if err == nil {
+		err = b.afterBind(i, s)
+	}
+	// field errors collected by BindAll fail the bind as well
+	if err == nil && len(s.errs) == 0 && !s.timedOut() && b.Validator != nil && i != nil {
+		err = b.Validator(i)
+	}
+	if s.timedOut() {
+		if err == nil || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrBindTimeout) {
+			err = ErrBindTimeout
+		} else {
+			err = errors.Join(ErrBindTimeout, err)
+		}
+	}
+	if len(s.errs) == 0 {
+		return err
+	}
+	return errors.Join(append(s.errs, err)...)
+}
+
+// timedOut reports whether DefaultBinder.Timeout of the operation was exceeded
+func (s *bindState) timedOut() bool {
+	return s.opCtx != nil && errors.Is(s.opCtx.Err(), context.DeadlineExceeded)
+}
+
+// deadlineReader fails reads with ErrBindTimeout once bind operation timed out
+type deadlineReader struct {
+	s *bindState
+	io.ReadCloser
+}
+
+func (d deadlineReader) Read(p []byte) (int, error) {
+	if d.s.timedOut() {
+		return 0, ErrBindTimeout
+	}
+	return d.ReadCloser.Read(p)
+}
+
+// withFieldTimeout runs fn with state context limited by field `timeout` tag i.e. `timeout:"500ms"`. The context is
+// passed to ContextBindUnmarshaler implementations.
+func (s *bindState) withFieldTimeout(field reflect.StructField, fn func() error) error {
+	timeout, ok := field.Tag.Lookup("timeout")
+	if !ok {
+		return fn()
+	}
+	d, err := time.ParseDuration(timeout)
+	if err != nil {
+		return fmt.Errorf("invalid timeout tag %q of field %s: %w", timeout, field.Name, err)
+	}
+
+	parent := s.ctx
+	ctx, cancel := context.WithTimeout(parent, d)
+	defer func() {
+		cancel()
+		s.ctx = parent
+	}()
+	s.ctx = ctx
+	return fn()
+}
+
+// afterBind runs steps that need all sources of the bind operation bound, like computed defaults
+func (b *DefaultBinder) afterBind(i interface{}, s *bindState) error {
+	val := reflect.ValueOf(i)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	if err := applyComputedDefaults(s, "", val.Elem()); err != nil {
+		return err
+	}
+	if err := applyTemplates(s, "", val.Elem()); err != nil {
+		return err
+	}
+	if !s.groups {
+		return nil
+	}
+	return checkFieldGroups(s, val.Elem())
 }
 
 // bindData will bind data ONLY fields in destination struct that have EXPLICIT tag
-func bindData(destination interface{}, data map[string][]string, tag string) error {
-	if destination == nil || len(data) == 0 {
+// path is the dot separated path of destination from the root struct and is empty for the root.
+func bindData(s *bindState, path string, destination interface{}, data map[string][]string, tag string) error {
+	// struct destinations are walked even when source has no data so that missing required fields are reported and
+	// defaults applied
+	if destination == nil {
 		return nil
 	}
+	if s.timedOut() {
+		return ErrBindTimeout
+	}
 	typ := reflect.TypeOf(destination).Elem()
 	val := reflect.ValueOf(destination).Elem()
 
-	// Support binding to limited Map destinations:
+	// Support binding to Map destinations, fast path for:
 	// - map[string][]string,
 	// - map[string]string <-- (binds first value from data slice)
 	// - map[string]interface{}
+	// other element types (i.e. map[string]int, map[string][]int) are converted with the regular conversion rules.
 	// You are better off binding to struct but there are user who want this map feature. Source of data for these cases are:
 	// params,query,header,form as these sources produce string values, most of the time slice of strings, actually.
 	if typ.Kind() == reflect.Map && typ.Key().Kind() == reflect.String {
+		if len(data) == 0 {
+			return nil
+		}
 		k := typ.Elem().Kind()
 		isElemInterface := k == reflect.Interface
-		isElemString := k == reflect.String
-		isElemSliceOfStrings := k == reflect.Slice && typ.Elem().Elem().Kind() == reflect.String
-		if !(isElemSliceOfStrings || isElemString || isElemInterface) {
+		isElemString := typ.Elem() == reflect.TypeOf("")
+		isElemSliceOfStrings := typ.Elem() == reflect.TypeOf([]string(nil))
+		if !(isElemSliceOfStrings || isElemString || isElemInterface) && !isConvertibleType(typ.Elem()) {
 			return nil
 		}
 		if val.IsNil() {
 			val.Set(reflect.MakeMap(typ))
 		}
 		for k, v := range data {
-			if isElemString {
-				val.SetMapIndex(reflect.ValueOf(k), reflect.ValueOf(v[0]))
-			} else {
-				val.SetMapIndex(reflect.ValueOf(k), reflect.ValueOf(v))
+			key := reflect.ValueOf(k).Convert(typ.Key())
+			switch {
+			case isElemString:
+				val.SetMapIndex(key, reflect.ValueOf(v[0]))
+			case isElemSliceOfStrings || isElemInterface:
+				val.SetMapIndex(key, reflect.ValueOf(v))
+			default:
+				elem := reflect.New(typ.Elem()).Elem()
+				if err := setField(s, fieldInput{tag: tag, path: k, name: k, values: v}, elem); err != nil {
+					be := &BindingError{Field: k, Values: v, Message: err.Error(), InternalError: err}
+					if err = s.fieldError(tag, k, k, v, be); err != nil {
+						return err
+					}
+					continue
+				}
+				val.SetMapIndex(key, elem)
 			}
 		}
 		return nil
@@ -167,148 +1268,733 @@ func bindData(destination interface{}, data map[string][]string, tag string) err
 
 	// !struct
 	if typ.Kind() != reflect.Struct {
-		if tag == "param" || tag == "query" || tag == "header" {
+		if len(data) == 0 && len(s.files) == 0 || tag == "param" || tag == "query" || tag == "header" || tag == "cookie" {
 			// incompatible type, data is probably to be found in the body
 			return nil
 		}
 		return errors.New("binding element must be a struct")
 	}
 
-	for i := 0; i < typ.NumField(); i++ {
-		typeField := typ.Field(i)
-		structField := val.Field(i)
-		if typeField.Anonymous {
-			if structField.Kind() == reflect.Ptr {
+	var folded map[string][]string
+	schema := s.binder.SchemaCompat && (tag == "query" || tag == "form")
+	for _, fp := range planFor(typ, tag, s.binder.nameTag(tag), schema).fields {
+		typeField := fp.field
+		structField := val.Field(fp.index)
+		if fp.embeddedPtr {
+			if !structField.IsNil() {
 				structField = structField.Elem()
+			} else if fp.name == "" && fp.resolver == "" && structField.CanSet() {
+				// untagged embedded pointer is flattened into a new struct, kept only when something was bound into it
+				elem := reflect.New(structField.Type().Elem())
+				if err := bindData(s, fieldPath(path, typeField), elem.Interface(), data, tag); err != nil {
+					return err
+				}
+				if !elem.Elem().IsZero() {
+					structField.Set(elem)
+				}
+				continue
 			}
+			// tagged one is namespaced, pointer is allocated once its keys are found
 		}
 		if !structField.CanSet() {
 			continue
 		}
-		structFieldKind := structField.Kind()
-		inputFieldName := typeField.Tag.Get(tag)
-		if typeField.Anonymous && structFieldKind == reflect.Struct && inputFieldName != "" {
-			// if anonymous struct with query/param/form tags, report an error
-			return errors.New("query/param/form tags are not allowed with anonymous struct field")
+		inputFieldName, nameErr := s.binder.substituteNames(fp.name)
+		if nameErr == nil && fp.switchSpec != "" && inputFieldName != "" {
+			inputFieldName, nameErr = s.switchedName(fp.switchSpec, inputFieldName)
+		}
+		if nameErr != nil {
+			return nameErr
+		}
+		tagOpts := fp.opts
+		overridden := false
+		if s.overrides != nil {
+			inputFieldName, tagOpts, overridden = s.overriddenName(fieldPath(path, typeField), tag, typeField, inputFieldName, tagOpts)
+			if overridden && inputFieldName == "" {
+				// bound from the overriding source
+				continue
+			}
 		}
 
-		if inputFieldName == "" {
+		resolverName := fp.resolver
+		if inputFieldName == "" && resolverName == "" {
 			// If tag is nil, we inspect if the field is a not BindUnmarshaler struct and try to bind data into it (might contains fields with tags).
 			// structs that implement BindUnmarshaler are bound only when they have explicit tag
-			if _, ok := structField.Addr().Interface().(BindUnmarshaler); !ok && structFieldKind == reflect.Struct {
-				if err := bindData(structField.Addr().Interface(), data, tag); err != nil {
+			if fp.nested {
+				if err := bindData(s, fieldPath(path, typeField), structField.Addr().Interface(), data, tag); err != nil {
 					return err
 				}
 			}
 			// does not have explicit tag and is not an ordinary struct - so move to next field
 			continue
 		}
+		if s.overriddenOnly && !overridden {
+			continue
+		}
 
-		inputValue, exists := data[inputFieldName]
-		if !exists {
-			// Go json.Unmarshal supports case insensitive binding.  However the
-			// url params are bound case sensitive which is inconsistent.  To
-			// fix this we must check all of the map values in a
-			// case-insensitive search.
-			for k, v := range data {
-				if strings.EqualFold(k, inputFieldName) {
-					inputValue = v
-					exists = true
-					break
+		if fp.file {
+			if tag != "form" || s.files == nil {
+				continue
+			}
+			if err := bindFiles(s, inputFieldName, tagOpts, structField); err != nil {
+				if err = s.fieldError(tag, inputFieldName, fieldPath(path, typeField), nil, err); err != nil {
+					return err
 				}
 			}
-		}
-
-		if !exists {
 			continue
 		}
 
-		// NOTE: algorithm here is not particularly sophisticated. It probably does not work with absurd types like `**[]*int`
-		// but it is smart enough to handle niche cases like `*int`,`*[]string`,`[]*int` .
+		if fp.namespaced && resolverName == "" && !(fp.set && hasKey(data, inputFieldName, s.binder.CaseSensitive)) {
+			if _, ok := lookupConverter(structField.Type()); !ok {
+				if err := bindNamespaced(s, path, tag, inputFieldName, tagOpts, typeField, structField, data); err != nil {
+					return err
+				}
+				continue
+			}
+		}
 
-		// try unmarshalling first, in case we're dealing with an alias to an array type
-		if ok, err := unmarshalInputsToField(typeField.Type.Kind(), inputValue, structField); ok {
+		var inputValue []string
+		exists := false
+		if fp.meta != "" {
+			// metadata of uploaded files, form values of the same name are ignored
+			var err error
+			if inputValue, exists, err = fileMeta(s, inputFieldName, fp.meta); err != nil {
+				return err
+			}
+		} else if resolverName != "" {
+			// resolver wins over tag name lookup
+			resolve, err := lookupResolver(resolverName)
 			if err != nil {
 				return err
 			}
-			continue
+			inputValue, exists = resolve(tag, data)
+		}
+		if !exists && inputFieldName != "" && fp.meta == "" {
+			if s.binder.CaseSensitive {
+				inputValue, exists = data[inputFieldName]
+			} else {
+				// Go json.Unmarshal supports case insensitive binding so keys are matched the same way. Index of folded
+				// keys is built once per call instead of scanning all keys for every field.
+				if folded == nil {
+					folded = foldKeys(data)
+				}
+				inputValue, exists = lookupFolded(data, folded, inputFieldName)
+			}
 		}
 
-		if ok, err := unmarshalInputToField(typeField.Type.Kind(), inputValue[0], structField); ok {
-			if err != nil {
-				return err
+		if isSliceType(typeField.Type) && inputFieldName != "" && fp.meta == "" {
+			// PHP/Rails style indexed notation `items[0]=a&items[1]=b` combines with repeated bare keys
+			if indexed, ok := indexedValues(data, inputFieldName, s.binder.CaseSensitive); ok {
+				inputValue, exists = append(append([]string(nil), inputValue...), indexed...), true
 			}
-			continue
 		}
 
-		// we could be dealing with pointer to slice `*[]string` so dereference it. There are wierd OpenAPI generators
-		// that could create struct fields like that.
-		if structFieldKind == reflect.Pointer {
-			structFieldKind = structField.Elem().Kind()
-			structField = structField.Elem()
+		if format := typeField.Tag.Get("format"); !exists && tag == "header" && (format == "client-ip" || format == "tenant") {
+			// client IP falls back to the connection address without X-Forwarded-For, tenant to the host subdomain
+			inputValue, exists = []string{}, true
 		}
 
-		if structFieldKind == reflect.Slice {
-			sliceOf := structField.Type().Elem().Kind()
-			numElems := len(inputValue)
-			slice := reflect.MakeSlice(structField.Type(), numElems, numElems)
-			for j := 0; j < numElems; j++ {
-				if err := setWithProperType(sliceOf, inputValue[j], slice.Index(j)); err != nil {
+		if !exists {
+			// checked before any conversion so pointer fields are reported as missing instead of being allocated. Required
+			// wins over `default` tag, default only applies to optional fields.
+			if tagOpts.contains("required") {
+				missing := &BindingError{
+					Field:         inputFieldName,
+					Values:        []string{},
+					Message:       "required field value is missing",
+					InternalError: requiredError(tag),
+				}
+				if err := s.fieldError(tag, inputFieldName, fieldPath(path, typeField), nil, missing); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := setStaticDefault(s, tag, fieldPath(path, typeField), typeField, structField); err != nil {
+				if err = s.fieldError(tag, inputFieldName, fieldPath(path, typeField), nil, err); err != nil {
 					return err
 				}
 			}
-			structField.Set(slice)
 			continue
 		}
+		// empty path segment is as good as missing one, required path params are always nonempty. Trimmed values are
+		// checked after trimming so blank values are empty, `required,trim` fields are nonempty as well.
+		checked := inputValue
+		if s.binder.TrimSpace || tagOpts.contains("trim") {
+			checked = mapValues(inputValue, strings.TrimSpace)
+		}
+		if mode, required := tagOpts.lookup("required"); (mode == "nonempty" || required && (tag == "param" || tagOpts.contains("trim"))) && allEmpty(checked) {
+			empty := &BindingError{
+				Field:         inputFieldName,
+				Values:        inputValue,
+				Message:       "required field value is empty",
+				InternalError: requiredError(tag),
+			}
+			if err := s.fieldError(tag, inputFieldName, fieldPath(path, typeField), inputValue, empty); err != nil {
+				return err
+			}
+			continue
+		}
+		s.present[fieldPath(path, typeField)] = true
+		if deprecated, _ := strconv.ParseBool(typeField.Tag.Get("deprecated")); deprecated && s.binder.OnDeprecated != nil {
+			s.binder.OnDeprecated(s.req, fieldPath(path, typeField), tag, inputFieldName)
+		}
+		if s.raw != nil {
+			s.raw[fieldPath(path, typeField)] = strings.Join(inputValue, ",")
+		}
+
+		err := bindField(s, path, tag, inputFieldName, tagOpts, inputValue, typeField, structField)
+		if sep, ok := typeField.Tag.Lookup("split"); ok && err == nil {
+			err = splitIntoSiblings(s, tag, path, sep, typeField, inputValue, val)
+		}
+		if err == nil {
+			err = s.recordBound(tag, inputFieldName, fieldPath(path, typeField), structField)
+		}
+		if err != nil {
+			if err = s.fieldError(tag, inputFieldName, fieldPath(path, typeField), inputValue, err); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// requiredError returns error matched by missing required field of source tag
+func requiredError(tag string) error {
+	if tag == "param" {
+		return ErrMissingPathParam
+	}
+	return ErrRequired
+}
+
+// bindNamespaced binds struct or map field tagged with name from keys prefixed with the name, `name.key` or `name[key]`
+func bindNamespaced(s *bindState, path, tag, name string, opts tagOptions, typeField reflect.StructField, structField reflect.Value, data map[string][]string) error {
+	sub := namespaceData(data, name, s.binder.CaseSensitive)
+	if len(sub) == 0 {
+		if !opts.contains("required") {
+			return nil
+		}
+		missing := &BindingError{
+			Field:         name,
+			Values:        []string{},
+			Message:       "required field value is missing",
+			InternalError: requiredError(tag),
+		}
+		return s.fieldError(tag, name, fieldPath(path, typeField), nil, missing)
+	}
+	s.present[fieldPath(path, typeField)] = true
+	if target := allocPointer(structField); target.Kind() == reflect.Map {
+		return bindMapEntries(s, path, tag, name, typeField, target, sub)
+	}
+	defer func(prefix string) { s.keyPrefix = prefix }(s.keyPrefix)
+	s.keyPrefix += name + "."
+	return bindData(s, fieldPath(path, typeField), allocPointer(structField).Addr().Interface(), sub, tag)
+}
+
+// bindField preprocesses input values of a single field found under inputFieldName and converts them into the field
+func bindField(s *bindState, path, tag, inputFieldName string, tagOpts tagOptions, inputValue []string, typeField reflect.StructField, structField reflect.Value) error {
+	if err := checkValueLimits(inputFieldName, inputValue, typeField); err != nil {
+		return err
+	}
+	if name := typeField.Tag.Get("decrypt"); name != "" {
+		decrypted, err := decryptValues(name, inputFieldName, inputValue)
+		if err != nil {
+			return err
+		}
+		inputValue = decrypted
+	}
+	if name := typeField.Tag.Get("normalize"); name != "" {
+		normalized, err := normalizeValues(name, inputValue)
+		if err != nil {
+			return err
+		}
+		inputValue = normalized
+	}
+
+	// `,trim` and `,lower` tag options apply to values of every type, before they are parsed
+	if s.binder.TrimSpace || tagOpts.contains("trim") {
+		inputValue = mapValues(inputValue, strings.TrimSpace)
+	}
+	if tagOpts.contains("lower") {
+		inputValue = mapValues(inputValue, strings.ToLower)
+	}
+	if pre := s.binder.StringPreprocessor; pre != nil && baseKind(typeField.Type) == reflect.String {
+		inputValue = mapValues(inputValue, pre)
+	}
+	if prefix, ok := typeField.Tag.Lookup("trimprefix"); ok {
+		inputValue = mapValues(inputValue, func(v string) string { return strings.TrimPrefix(v, prefix) })
+	}
+	if suffix, ok := typeField.Tag.Lookup("trimsuffix"); ok {
+		inputValue = mapValues(inputValue, func(v string) string { return strings.TrimSuffix(v, suffix) })
+	}
+	// OpenAPI `explode=false` style lists i.e. `?tags=a,b,c` are split into elements of slice field, repeated keys
+	// combine with split values
+	if delim := typeField.Tag.Get("delim"); delim != "" && isSliceType(typeField.Type) {
+		var split []string
+		for _, v := range inputValue {
+			split = append(split, strings.Split(v, delim)...)
+		}
+		inputValue = split
+	}
+	// repeated values are joined into single value in order of appearance i.e. `join:"" format:"base64"` for base64
+	// value split into chunks `?d=part1&d=part2`
+	if sep, ok := typeField.Tag.Lookup("join"); ok {
+		inputValue = []string{strings.Join(inputValue, sep)}
+	}
 
-		if err := setWithProperType(structFieldKind, inputValue[0], structField); err != nil {
+	// fields with explicit `format` tag are validated or decoded by format specific decoder i.e. `format:"json"` for
+	// `?ids=[1,2,3]`
+	if validate, ok := formatValidators[typeField.Tag.Get("format")]; ok {
+		for _, v := range inputValue {
+			if err := validate(v); err != nil {
+				return err
+			}
+		}
+	}
+	if unit := typeField.Tag.Get("unit"); unit != "" {
+		converted, err := applyUnit(unit, inputValue)
+		if err != nil {
+			return err
+		}
+		inputValue = converted
+	}
+	in := fieldInput{tag: tag, path: fieldPath(path, typeField), name: inputFieldName, field: typeField, values: inputValue}
+	err := s.withFieldTimeout(typeField, func() error {
+		if format, ok := formats[typeField.Tag.Get("format")]; ok {
+			return format(s, in, structField)
+		}
+		if err := setField(s, in, structField); err != nil {
+			return err
+		}
+		if places, ok := typeField.Tag.Lookup("round"); ok {
+			if err := roundField(places, structField); err != nil {
+				return err
+			}
+		}
+		if clamp, ok := typeField.Tag.Lookup("clamp"); ok {
+			if err := clampField(clamp, structField); err != nil {
+				return err
+			}
+		}
+		if name := typeField.Tag.Get("aliases"); name != "" {
+			if err := applyAliases(name, typeField, structField); err != nil {
+				return err
+			}
+		}
+		if name := typeField.Tag.Get("sanitize"); name != "" {
+			return s.sanitizeField(name, structField)
+		}
+		return nil
+	})
+	if err != nil {
+		if typeField.Tag.Get("onerror") != "default" {
 			return err
 		}
+		// lenient field, fall back to its default (or zero value) as if input was absent
+		structField.SetZero()
+		delete(s.present, in.path)
+		return setStaticDefault(s, tag, in.path, typeField, structField)
 	}
 	return nil
 }
 
-func setWithProperType(valueKind reflect.Kind, val string, structField reflect.Value) error {
+// scalarValues returns values single value field of type typ binds according to DefaultBinder.MultiValueScalarPolicy.
+// Values of slices, arrays and types converting all values themselves are returned as is.
+func (s *bindState) scalarValues(values []string, typ reflect.Type) ([]string, error) {
+	if len(values) < 2 || s.binder.MultiValueScalarPolicy == MultiValueFirstWins {
+		return values, nil
+	}
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array || isSetType(typ) ||
+		reflect.PointerTo(typ).Implements(bindMultipleUnmarshalerType) {
+		return values, nil
+	}
+	if _, ok := lookupConverter(typ); ok {
+		return values, nil
+	}
+	if s.binder.MultiValueScalarPolicy == MultiValueError {
+		return nil, fmt.Errorf("%w: got %d", ErrMultipleValues, len(values))
+	}
+	return values[len(values)-1:], nil
+}
+
+// setField binds input values into field using the regular conversion rules
+func setField(s *bindState, in fieldInput, structField reflect.Value) error {
+	inputValue := in.values
+	structFieldKind := structField.Kind()
+
+	// NOTE: algorithm here is not particularly sophisticated. It probably does not work with absurd types like `**[]*int`
+	// but it is smart enough to handle niche cases like `*int`,`*[]string`,`[]*int` .
+
+	inputValue, err := s.scalarValues(inputValue, structField.Type())
+	if err != nil {
+		return err
+	}
+	in.values = inputValue
+
+	// pointer receiving only empty values stays nil, so `?n=` remains distinguishable from `?n=0`
+	if structFieldKind == reflect.Pointer && allEmpty(inputValue) && s.emptyLeavesNil(in.tag, structField.Type().Elem()) {
+		return nil
+	}
+
+	// try unmarshalling first, in case we're dealing with an alias to an array type
+	if ok, err := unmarshalInputsToField(structFieldKind, inputValue, structField); ok {
+		return err
+	}
+
+	if ok, err := s.setTimeField(&in, inputValue[0], structField); ok {
+		return err
+	}
+
+	if ok, err := unmarshalInputToField(s, &in, structFieldKind, inputValue[0], structField); ok {
+		return err
+	}
+
+	if ok, err := convertField(inputValue, structField); ok {
+		return err
+	}
+
+	// we could be dealing with pointer to slice `*[]string` so dereference it. There are wierd OpenAPI generators
+	// that could create struct fields like that.
+	if structFieldKind == reflect.Pointer {
+		structFieldKind = structField.Elem().Kind()
+		structField = structField.Elem()
+	}
+
+	if structFieldKind == reflect.Map && isSetType(structField.Type()) {
+		// every value is a member of the set, empty values are not
+		set := reflect.MakeMap(structField.Type())
+		member := reflect.New(structField.Type().Elem()).Elem()
+		if member.Kind() == reflect.Bool {
+			member.SetBool(true)
+		}
+		keyOf := structField.Type().Key().Kind()
+		for _, value := range inputValue {
+			if value == "" {
+				continue
+			}
+			key := reflect.New(structField.Type().Key()).Elem()
+			if err := setWithProperType(s, &in, keyOf, value, key); err != nil {
+				return err
+			}
+			set.SetMapIndex(key, member)
+		}
+		structField.Set(set)
+		return nil
+	}
+
+	if structFieldKind == reflect.Slice {
+		if allEmpty(inputValue) && s.binder.EmptySliceClears {
+			// present but empty list clears the field, absent one leaves it nil
+			structField.Set(reflect.MakeSlice(structField.Type(), 0, 0))
+			return nil
+		}
+		sliceOf := structField.Type().Elem().Kind()
+		numElems := len(inputValue)
+		slice := reflect.MakeSlice(structField.Type(), numElems, numElems)
+		n := 0
+		for j := 0; j < numElems; j++ {
+			if err := setWithProperType(s, &in, sliceOf, inputValue[j], slice.Index(n)); err != nil {
+				if s.binder.SliceErrorMode != SliceErrorSkip {
+					return err
+				}
+				if s.binder.OnSkippedElement != nil {
+					s.binder.OnSkippedElement(in.path, inputValue[j], err)
+				}
+				slice.Index(n).SetZero()
+				continue
+			}
+			n++
+		}
+		structField.Set(slice.Slice(0, n))
+		return nil
+	}
+
+	// fixed size arrays are filled up to their length, surplus input values are ignored like they are for
+	// non-slice fields and slots without input value are left zero
+	if structFieldKind == reflect.Array {
+		arrayOf := structField.Type().Elem().Kind()
+		array := reflect.New(structField.Type()).Elem()
+		for j := 0; j < len(inputValue) && j < array.Len(); j++ {
+			if err := setWithProperType(s, &in, arrayOf, inputValue[j], array.Index(j)); err != nil {
+				return err
+			}
+		}
+		structField.Set(array)
+		return nil
+	}
+
+	return setWithProperType(s, &in, structFieldKind, inputValue[0], structField)
+}
+
+// emptyLeavesNil reports whether pointer to typ is left nil for empty input instead of pointing to zero value. Strings
+// (empty string is a value of its own), bools binding empty value as true or rejecting it and unmarshalers, which
+// decide about empty input themselves, are allocated. Hex encoded binary numbers (LEUint16 etc.) stay nil like numbers.
+func (s *bindState) emptyLeavesNil(tag string, typ reflect.Type) bool {
+	if _, ok := binaryOrders[typ]; ok || typ == timeType || typ == durationType {
+		return true
+	}
+	if _, ok := lookupConverter(typ); ok {
+		return false
+	}
+	switch reflect.New(typ).Interface().(type) {
+	case bindMultipleUnmarshaler, ContextBindUnmarshaler, BindUnmarshaler, encoding.TextUnmarshaler, encoding.BinaryUnmarshaler:
+		return false
+	}
+	switch typ.Kind() {
+	case reflect.Bool:
+		return s.emptyBoolPolicy(tag) == EmptyBoolFalse
+	default:
+		return isNumberKind(typ.Kind())
+	}
+}
+
+// emptyBoolPolicy returns EmptyBoolPolicy of source tag
+func (s *bindState) emptyBoolPolicy(tag string) EmptyBoolPolicy {
+	source := Source(tag)
+	if tag == "form" {
+		source = SourceBody
+	}
+	if policy, ok := s.binder.EmptyBoolPolicies[source]; ok {
+		return policy
+	}
+	if s.binder.EmptyBoolIsTrue {
+		return EmptyBoolTrue
+	}
+	return EmptyBoolFalse
+}
+
+// mapValues returns copy of values with fn applied to every value. Input values are never modified in place as they
+// are shared with the request (i.e. r.Header).
+func mapValues(values []string, fn func(string) string) []string {
+	mapped := make([]string, len(values))
+	for i, v := range values {
+		mapped[i] = fn(v)
+	}
+	return mapped
+}
+
+// tagOptions are comma separated options following the name in a source tag i.e. `required` in `query:"id,required"`
+type tagOptions string
+
+// parseTag splits source tag into name and options
+func parseTag(tag string) (string, tagOptions) {
+	name, opts, _ := strings.Cut(tag, ",")
+	return name, tagOptions(opts)
+}
+
+// substituteNames replaces `${NAME}` references in tag name with values of TagNames
+func (b *DefaultBinder) substituteNames(name string) (string, error) {
+	if !strings.Contains(name, "${") {
+		return name, nil
+	}
+	var missing string
+	expanded := os.Expand(name, func(key string) string {
+		v, ok := b.TagNames[key]
+		if !ok && missing == "" {
+			missing = key
+		}
+		return v
+	})
+	if missing != "" {
+		return "", fmt.Errorf("tag name %q references unknown name %q", name, missing)
+	}
+	return expanded, nil
+}
+
+// contains reports whether options include given option, with or without value i.e. `required` or `required=nonempty`
+func (o tagOptions) contains(option string) bool {
+	_, ok := o.lookup(option)
+	return ok
+}
+
+// lookup returns value of `<option>=<value>` option, empty for option without value, and reports whether options
+// include the option
+func (o tagOptions) lookup(option string) (string, bool) {
+	s := string(o)
+	for s != "" {
+		var opt string
+		opt, s, _ = strings.Cut(s, ",")
+		name, value, _ := strings.Cut(strings.TrimSpace(opt), "=")
+		if name == option {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// foldKeys indexes keys of data by their lower case form. Keys with the same form are sorted so lookups are
+// deterministic.
+func foldKeys(data map[string][]string) map[string][]string {
+	folded := make(map[string][]string, len(data))
+	for k := range data {
+		lower := strings.ToLower(k)
+		folded[lower] = append(folded[lower], k)
+	}
+	for _, keys := range folded {
+		sort.Strings(keys)
+	}
+	return folded
+}
+
+// lookupFolded returns values of all keys matching name case-insensitively. Values of exactly matching key come
+// first, followed by other variants in sorted key order, so result does not depend on whether exact key was sent.
+func lookupFolded(data map[string][]string, folded map[string][]string, name string) ([]string, bool) {
+	keys := folded[strings.ToLower(name)]
+	switch len(keys) {
+	case 0:
+		return nil, false
+	case 1:
+		return data[keys[0]], true
+	}
+	values := append([]string(nil), data[name]...)
+	for _, k := range keys {
+		if k != name {
+			values = append(values, data[k]...)
+		}
+	}
+	return values, true
+}
+
+// isSliceType reports whether t is a slice or pointer to slice
+func isSliceType(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice || t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Slice
+}
+
+// allEmpty reports whether all values are empty strings
+func allEmpty(values []string) bool {
+	for _, v := range values {
+		if v != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// fieldPath returns path of the struct field in relation to the root struct. Anonymous fields are promoted so they do not
+// add a path segment.
+func fieldPath(path string, field reflect.StructField) string {
+	if field.Anonymous {
+		return path
+	}
+	if path == "" {
+		return field.Name
+	}
+	return path + "." + field.Name
+}
+
+// baseKind returns kind of the type with pointers, slices and arrays stripped i.e. `String` for `*[]string`
+func baseKind(typ reflect.Type) reflect.Kind {
+	for {
+		switch typ.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array:
+			typ = typ.Elem()
+		default:
+			return typ.Kind()
+		}
+	}
+}
+
+func setWithProperType(s *bindState, in *fieldInput, valueKind reflect.Kind, val string, structField reflect.Value) error {
+	// time is parsed before unmarshalers as time.Time implements encoding.TextUnmarshaler (for RFC3339 only)
+	if ok, err := s.setTimeField(in, val, structField); ok {
+		return err
+	}
+
 	// But also call it here, in case we're dealing with an array of BindUnmarshalers
-	if ok, err := unmarshalInputToField(valueKind, val, structField); ok {
+	if ok, err := unmarshalInputToField(s, in, valueKind, val, structField); ok {
+		return err
+	}
+
+	if ok, err := convertField([]string{val}, structField); ok {
 		return err
 	}
 
+	if isIntegerKind(valueKind) {
+		base, err := s.numberBase(in, structField.Type())
+		if err != nil {
+			return err
+		}
+		if base != 10 {
+			return s.translateError(in, valueKind, val, setBaseNumber(val, base, structField))
+		}
+	}
+
+	if isNumberKind(valueKind) {
+		var err error
+		if val, err = s.localeNumber(in, val); err != nil {
+			return err
+		}
+	}
+
+	var err error
 	switch valueKind {
 	case reflect.Ptr:
-		return setWithProperType(structField.Elem().Kind(), val, structField.Elem())
+		return setWithProperType(s, in, structField.Elem().Kind(), val, structField.Elem())
 	case reflect.Int:
-		return setIntField(val, 0, structField)
+		err = setIntField(val, 0, structField)
 	case reflect.Int8:
-		return setIntField(val, 8, structField)
+		err = setIntField(val, 8, structField)
 	case reflect.Int16:
-		return setIntField(val, 16, structField)
+		err = setIntField(val, 16, structField)
 	case reflect.Int32:
-		return setIntField(val, 32, structField)
+		err = setIntField(val, 32, structField)
 	case reflect.Int64:
-		return setIntField(val, 64, structField)
+		err = setIntField(val, 64, structField)
 	case reflect.Uint:
-		return setUintField(val, 0, structField)
+		err = setUintField(val, 0, structField)
 	case reflect.Uint8:
-		return setUintField(val, 8, structField)
+		err = setUintField(val, 8, structField)
 	case reflect.Uint16:
-		return setUintField(val, 16, structField)
+		err = setUintField(val, 16, structField)
 	case reflect.Uint32:
-		return setUintField(val, 32, structField)
+		err = setUintField(val, 32, structField)
 	case reflect.Uint64:
-		return setUintField(val, 64, structField)
+		err = setUintField(val, 64, structField)
 	case reflect.Bool:
-		return setBoolField(val, structField)
+		if val == "" {
+			switch s.emptyBoolPolicy(in.tag) {
+			case EmptyBoolTrue:
+				val = "true"
+			case EmptyBoolError:
+				return ErrEmptyBool
+			}
+		}
+		if b, ok := s.binder.boolLiteral(val); ok {
+			structField.SetBool(b)
+			return nil
+		}
+		err = setBoolField(val, structField)
 	case reflect.Float32:
-		return setFloatField(val, 32, structField)
+		err = setFloatField(val, 32, structField)
 	case reflect.Float64:
-		return setFloatField(val, 64, structField)
+		err = setFloatField(val, 64, structField)
+	case reflect.Complex64:
+		err = setComplexField(val, 64, structField)
+	case reflect.Complex128:
+		err = setComplexField(val, 128, structField)
 	case reflect.String:
+		if intern := s.binder.StringInterner; intern != nil {
+			val = intern(val)
+		}
 		structField.SetString(val)
 	default:
-		return errors.New("unknown type")
+		return unsupportedTypeError(in, structField.Type())
 	}
-	return nil
+	return s.translateError(in, valueKind, val, err)
+}
+
+// translateError returns conversion error of input value val replaced by DefaultBinder.ErrorTranslator
+func (s *bindState) translateError(in *fieldInput, kind reflect.Kind, val string, err error) error {
+	if err == nil || s.binder.ErrorTranslator == nil {
+		return err
+	}
+	name := in.name
+	if name == "" {
+		name = in.path
+	}
+	return s.binder.ErrorTranslator(name, in.tag, val, kind, err)
 }
 
 func unmarshalInputsToField(valueKind reflect.Kind, values []string, field reflect.Value) (bool, error) {
@@ -327,7 +2013,11 @@ func unmarshalInputsToField(valueKind reflect.Kind, values []string, field refle
 	return true, unmarshaler.UnmarshalParams(values)
 }
 
-func unmarshalInputToField(valueKind reflect.Kind, val string, field reflect.Value) (bool, error) {
+// unmarshalInputToField binds val into field implementing an unmarshaler, in order of precedence ContextBindUnmarshaler,
+// BindUnmarshaler, encoding.TextUnmarshaler and encoding.BinaryUnmarshaler. Binary unmarshaler receives the raw bytes
+// of val or, with `,base64` option of the source tag, its base64 decoded bytes. Results of ContextBindUnmarshaler and
+// BindUnmarshaler are cached by DefaultBinder.UnmarshalCache.
+func unmarshalInputToField(s *bindState, in *fieldInput, valueKind reflect.Kind, val string, field reflect.Value) (bool, error) {
 	if valueKind == reflect.Ptr {
 		if field.IsNil() {
 			field.Set(reflect.New(field.Type().Elem()))
@@ -337,10 +2027,21 @@ func unmarshalInputToField(valueKind reflect.Kind, val string, field reflect.Val
 
 	fieldIValue := field.Addr().Interface()
 	switch unmarshaler := fieldIValue.(type) {
+	case ContextBindUnmarshaler:
+		return true, s.cachedUnmarshal(val, field, func() error { return unmarshaler.UnmarshalParamContext(s.ctx, val) })
 	case BindUnmarshaler:
-		return true, unmarshaler.UnmarshalParam(val)
+		return true, s.cachedUnmarshal(val, field, func() error { return unmarshaler.UnmarshalParam(val) })
 	case encoding.TextUnmarshaler:
 		return true, unmarshaler.UnmarshalText([]byte(val))
+	case encoding.BinaryUnmarshaler:
+		data := []byte(val)
+		if _, opts := parseTag(in.field.Tag.Get(in.tag)); opts.contains("base64") {
+			var err error
+			if data, err = base64.StdEncoding.DecodeString(val); err != nil {
+				return true, fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+			}
+		}
+		return true, unmarshaler.UnmarshalBinary(data)
 	}
 
 	return false, nil
@@ -368,6 +2069,22 @@ func setUintField(value string, bitSize int, field reflect.Value) error {
 	return err
 }
 
+// defaultBoolLiterals are BoolLiterals of binder that has none set
+var defaultBoolLiterals = map[string]bool{"on": true, "off": false, "yes": true, "no": false}
+
+func (b *DefaultBinder) boolLiteral(value string) (bool, bool) {
+	literals := b.BoolLiterals
+	if literals == nil {
+		literals = defaultBoolLiterals
+	}
+	for literal, v := range literals {
+		if strings.EqualFold(literal, value) {
+			return v, true
+		}
+	}
+	return false, false
+}
+
 func setBoolField(value string, field reflect.Value) error {
 	if value == "" {
 		value = "false"
@@ -379,6 +2096,30 @@ func setBoolField(value string, field reflect.Value) error {
 	return err
 }
 
+// unsupportedTypeError returns error identifying source, input key and field that could not be bound into type typ
+func unsupportedTypeError(in *fieldInput, typ reflect.Type) error {
+	name := in.name
+	if name == "" {
+		name, _ = parseTag(in.field.Tag.Get(in.tag))
+	}
+	field := in.path
+	if field == "" {
+		field = in.field.Name
+	}
+	return fmt.Errorf("cannot bind %q into field %s of type %v: %w", in.tag+":"+name, field, typ, ErrUnsupportedType)
+}
+
+func setComplexField(value string, bitSize int, field reflect.Value) error {
+	if value == "" {
+		value = "0"
+	}
+	complexVal, err := strconv.ParseComplex(value, bitSize)
+	if err == nil {
+		field.SetComplex(complexVal)
+	}
+	return err
+}
+
 func setFloatField(value string, bitSize int, field reflect.Value) error {
 	if value == "" {
 		value = "0.0"
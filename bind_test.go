@@ -0,0 +1,2219 @@
+package binding
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newRequest creates request with body of content type, empty content type leaves the header unset
+func newRequest(method, target, contentType, body string) *http.Request {
+	var r io.Reader
+	if body != "" {
+		r = strings.NewReader(body)
+	}
+	req := httptest.NewRequest(method, target, r)
+	if contentType != "" {
+		req.Header.Set(HeaderContentType, contentType)
+	}
+	return req
+}
+
+// newMultipartRequest creates multipart/form-data request with text fields written in order of pairs (key, value, ...)
+// followed by files of files pairs (field name, content, ...)
+func newMultipartRequest(t *testing.T, target string, pairs []string, files []string) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if err := w.WriteField(pairs[i], pairs[i+1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i+1 < len(files); i += 2 {
+		part, err := w.CreateFormFile(files[i], fmt.Sprintf("%s%d.txt", files[i], i/2))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := part.Write([]byte(files[i+1])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(http.MethodPost, target, &body)
+	req.Header.Set(HeaderContentType, w.FormDataContentType())
+	return req
+}
+
+type requiredRef struct{ Slug string }
+
+func (r *requiredRef) UnmarshalParam(param string) error {
+	r.Slug = param
+	return nil
+}
+
+func TestBindQueryParams_RequiredPointers(t *testing.T) {
+	type query struct {
+		ID   *int         `query:"id,required"`
+		Name *string      `query:"name,required"`
+		Ref  *requiredRef `query:"ref,required"`
+	}
+	for _, extra := range []url.Values{{}, {"x": {"1"}}} {
+		for _, field := range []string{"id", "name", "ref"} {
+			params := url.Values{"id": {"1"}, "name": {"a"}, "ref": {"r"}}
+			for k, v := range extra {
+				params[k] = v
+			}
+			params.Del(field)
+			target := "/?" + params.Encode()
+
+			var dest query
+			err := BindQueryParams(newRequest(http.MethodGet, target, "", ""), &dest)
+			if !errors.Is(err, ErrRequired) {
+				t.Fatalf("%s: expected ErrRequired, got %v", target, err)
+			}
+			var be *BindingError
+			if !errors.As(err, &be) || be.Field != field {
+				t.Fatalf("%s: expected BindingError of %s, got %v", target, field, err)
+			}
+		}
+	}
+}
+
+func TestBindQueryParams_RequiredEmptyQuery(t *testing.T) {
+	var dest struct {
+		ID *int `query:"id,required"`
+	}
+	if err := BindQueryParams(newRequest(http.MethodGet, "/", "", ""), &dest); !errors.Is(err, ErrRequired) {
+		t.Fatalf("expected ErrRequired for request without query, got %v", err)
+	}
+	if dest.ID != nil {
+		t.Fatalf("expected missing pointer field to stay nil, got %v", *dest.ID)
+	}
+}
+
+func TestBindQueryParams_RequiredPresent(t *testing.T) {
+	var dest struct {
+		ID   *int         `query:"id,required"`
+		Name *string      `query:"name,required"`
+		Ref  *requiredRef `query:"ref,required"`
+	}
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?id=7&name=&ref=r", "", ""), &dest); err != nil {
+		t.Fatal(err)
+	}
+	if *dest.ID != 7 || *dest.Name != "" || dest.Ref.Slug != "r" {
+		t.Fatalf("unexpected result %v %q %v", *dest.ID, *dest.Name, dest.Ref)
+	}
+}
+
+func TestBindHeaders_RequiredWithoutHeaders(t *testing.T) {
+	var dest struct {
+		Token string `header:"X-Token,required"`
+	}
+	req := newRequest(http.MethodGet, "/", "", "")
+	req.Header = http.Header{}
+	if err := BindHeaders(req, &dest); !errors.Is(err, ErrRequired) {
+		t.Fatalf("expected ErrRequired, got %v", err)
+	}
+}
+
+func TestBindCookies_RequiredWithoutCookies(t *testing.T) {
+	var dest struct {
+		Session string `cookie:"session,required"`
+	}
+	if err := BindCookies(newRequest(http.MethodGet, "/", "", ""), &dest); !errors.Is(err, ErrRequired) {
+		t.Fatalf("expected ErrRequired, got %v", err)
+	}
+}
+
+func TestBindForm_RequiredEmptyBody(t *testing.T) {
+	var dest struct {
+		Name string `form:"name,required"`
+	}
+	req := newRequest(http.MethodPost, "/", MIMEApplicationForm, "")
+	if err := BindForm(req, &dest); !errors.Is(err, ErrRequired) {
+		t.Fatalf("expected ErrRequired, got %v", err)
+	}
+}
+
+func TestBindBody_RepeatedMultipartFieldsIntoMap(t *testing.T) {
+	pairs := []string{"tag", "a", "tag", "b", "name", "n", "tag", "c"}
+	var dest map[string][]string
+	if err := BindBody(newMultipartRequest(t, "/", pairs, nil), &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string][]string{"tag": {"a", "b", "c"}, "name": {"n"}}
+	if !reflect.DeepEqual(dest, want) {
+		t.Errorf("got %v, want %v", dest, want)
+	}
+}
+
+func TestBindBody_RepeatedMultipartFieldsIntoTypedMap(t *testing.T) {
+	pairs := []string{"score", "1", "score", "2", "count", "3"}
+	var dest map[string][]int
+	if err := BindBody(newMultipartRequest(t, "/", pairs, nil), &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string][]int{"score": {1, 2}, "count": {3}}
+	if !reflect.DeepEqual(dest, want) {
+		t.Errorf("got %v, want %v", dest, want)
+	}
+}
+
+func TestBindBody_RepeatedMultipartFieldsMatchQuery(t *testing.T) {
+	type payload struct {
+		Tags []string `form:"tag" query:"tag"`
+		Name string   `form:"name" query:"name"`
+	}
+	var fromForm, fromQuery payload
+	if err := BindBody(newMultipartRequest(t, "/", []string{"tag", "a", "tag", "b", "name", "x", "name", "y"}, nil),
+		&fromForm); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := BindQueryParams(newRequest("GET", "/?tag=a&tag=b&name=x&name=y", "", ""), &fromQuery); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(fromForm, fromQuery) {
+		t.Errorf("form %+v differs from query %+v", fromForm, fromQuery)
+	}
+	if !reflect.DeepEqual(fromForm, payload{Tags: []string{"a", "b"}, Name: "x"}) {
+		t.Errorf("got %+v", fromForm)
+	}
+}
+
+type RequiredPaging struct {
+	Page int `query:"page,required"`
+}
+
+func TestBindQueryParams_RequiredNamesField(t *testing.T) {
+	var dest struct {
+		ID   int    `query:"id,required"`
+		Name string `query:"name"`
+	}
+	err := BindQueryParams(newRequest(http.MethodGet, "/?name=n", "", ""), &dest)
+	var be *BindingError
+	if !errors.As(err, &be) || !errors.Is(err, ErrRequired) {
+		t.Fatalf("expected *BindingError matching ErrRequired, got %v", err)
+	}
+	if be.Field != "id" {
+		t.Errorf("Field = %q, want id", be.Field)
+	}
+}
+
+func TestBindQueryParams_RequiredEmptyValue(t *testing.T) {
+	testCases := []struct {
+		name    string
+		query   string
+		wantErr bool
+	}{
+		{name: "absent", query: "code=x", wantErr: true},
+		{name: "present empty", query: "id=&code=x"},
+		{name: "present empty nonempty", query: "id=1&code=", wantErr: true},
+		{name: "present", query: "id=1&code=x"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var dest struct {
+				ID   int    `query:"id,required"`
+				Code string `query:"code,required=nonempty"`
+			}
+			err := BindQueryParams(newRequest(http.MethodGet, "/?"+tc.query, "", ""), &dest)
+			if gotErr := errors.Is(err, ErrRequired); gotErr != tc.wantErr {
+				t.Errorf("error = %v, want ErrRequired %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestBindQueryParams_RequiredComposesWithOptions(t *testing.T) {
+	var dest struct {
+		Tags []int `query:"tags,required" delim:"," default:"1,2"`
+	}
+	err := BindQueryParams(newRequest(http.MethodGet, "/", "", ""), &dest)
+	if !errors.Is(err, ErrRequired) {
+		t.Fatalf("expected ErrRequired instead of default, got %v", err)
+	}
+	if dest.Tags != nil {
+		t.Errorf("default applied to required field: %v", dest.Tags)
+	}
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?tags=3,4", "", ""), &dest); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dest.Tags, []int{3, 4}) {
+		t.Errorf("Tags = %v, want [3 4]", dest.Tags)
+	}
+}
+
+func TestBindQueryParams_RequiredEmbeddedAndNested(t *testing.T) {
+	type dest struct {
+		RequiredPaging
+		Filter struct {
+			Status string `query:"status,required"`
+		}
+	}
+	testCases := []struct {
+		query string
+		field string
+	}{
+		{query: "status=open", field: "page"},
+		{query: "page=1", field: "status"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.field, func(t *testing.T) {
+			var d dest
+			err := BindQueryParams(newRequest(http.MethodGet, "/?"+tc.query, "", ""), &d)
+			var be *BindingError
+			if !errors.As(err, &be) || !errors.Is(err, ErrRequired) || be.Field != tc.field {
+				t.Errorf("error = %v, want required %s", err, tc.field)
+			}
+		})
+	}
+	var d dest
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?page=2&status=open", "", ""), &d); err != nil {
+		t.Fatal(err)
+	}
+	if d.Page != 2 || d.Filter.Status != "open" {
+		t.Errorf("got %+v", d)
+	}
+}
+
+func TestBindQueryParams_DelimitedSlices(t *testing.T) {
+	type dest struct {
+		IDs    []int     `query:"ids" delim:","`
+		Tags   []string  `query:"tags" delim:"|"`
+		Scores *[]uint   `query:"scores" delim:":"`
+		Plain  []string  `query:"plain"`
+		Names  *[]string `query:"names" delim:","`
+	}
+	testCases := []struct {
+		name  string
+		query string
+		want  dest
+	}{
+		{
+			name:  "single values",
+			query: "ids=1,2,3&tags=a|b&scores=4:5&plain=x,y",
+			want:  dest{IDs: []int{1, 2, 3}, Tags: []string{"a", "b"}, Scores: &[]uint{4, 5}, Plain: []string{"x,y"}},
+		},
+		{
+			name:  "combined with repeated keys",
+			query: "ids=1,2&ids=3&tags=a&tags=b|c&plain=x&plain=y",
+			want:  dest{IDs: []int{1, 2, 3}, Tags: []string{"a", "b", "c"}, Plain: []string{"x", "y"}},
+		},
+		{
+			name:  "other delimiter is kept",
+			query: "tags=a,b&names=c|d,e",
+			want:  dest{Tags: []string{"a,b"}, Names: &[]string{"c|d", "e"}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var d dest
+			if err := BindQueryParams(newRequest(http.MethodGet, "/?"+tc.query, "", ""), &d); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(d, tc.want) {
+				t.Errorf("got %+v, want %+v", d, tc.want)
+			}
+		})
+	}
+}
+
+func TestBindQueryParams_DelimitedSliceInvalidElement(t *testing.T) {
+	var dest struct {
+		IDs []int `query:"ids" delim:","`
+	}
+	err := BindQueryParams(newRequest(http.MethodGet, "/?ids=1,x,3", "", ""), &dest)
+	var ne *strconv.NumError
+	if !errors.As(err, &ne) || ne.Num != "x" {
+		t.Fatalf("expected NumError of x, got %v", err)
+	}
+}
+
+func TestBindQueryParams_CaseVariantsCombined(t *testing.T) {
+	type dest struct {
+		IDs []string `query:"id"`
+		ID  string   `query:"id"`
+	}
+	testCases := []struct {
+		query  string
+		want   []string
+		wantID string
+	}{
+		{query: "id=1&ID=2", want: []string{"1", "2"}, wantID: "1"},
+		{query: "ID=2&id=1", want: []string{"1", "2"}, wantID: "1"},
+		{query: "Id=3&ID=2", want: []string{"2", "3"}, wantID: "2"},
+		{query: "iD=4", want: []string{"4"}, wantID: "4"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			var d dest
+			if err := BindQueryParams(newRequest(http.MethodGet, "/?"+tc.query, "", ""), &d); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(d.IDs, tc.want) || d.ID != tc.wantID {
+				t.Errorf("got %v %q, want %v %q", d.IDs, d.ID, tc.want, tc.wantID)
+			}
+		})
+	}
+
+	var d dest
+	b := NewBinder(WithCaseSensitive(true))
+	if err := b.BindQueryParams(newRequest(http.MethodGet, "/?ID=2&Id=3", "", ""), &d); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.IDs != nil || d.ID != "" {
+		t.Errorf("case sensitive binder bound %+v", d)
+	}
+}
+
+// benchFields is a struct with many fields bound from query
+type benchFields struct {
+	F01 string  `query:"f01"`
+	F02 string  `query:"f02"`
+	F03 string  `query:"f03"`
+	F04 string  `query:"f04"`
+	F05 string  `query:"f05"`
+	F06 int     `query:"f06"`
+	F07 int     `query:"f07"`
+	F08 int     `query:"f08"`
+	F09 int     `query:"f09"`
+	F10 int     `query:"f10"`
+	F11 bool    `query:"f11"`
+	F12 bool    `query:"f12"`
+	F13 float64 `query:"f13"`
+	F14 float64 `query:"f14"`
+	F15 *int    `query:"f15"`
+	F16 *string `query:"f16"`
+	F17 []int   `query:"f17"`
+	F18 []int   `query:"f18"`
+	F19 uint    `query:"f19"`
+	F20 uint    `query:"f20"`
+}
+
+// benchQuery sends all fields of benchFields with upper case keys, so every key is matched case-insensitively
+const benchQuery = "/?F01=a&F02=b&F03=c&F04=d&F05=e&F06=1&F07=2&F08=3&F09=4&F10=5&F11=true&F12=false&F13=1.5&F14=2.5" +
+	"&F15=7&F16=s&F17=1&F17=2&F18=3&F19=8&F20=9"
+
+func benchmarkBindQueryParams(b *testing.B, binder *DefaultBinder, target string) {
+	req := newRequest(http.MethodGet, target, "", "")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var dest benchFields
+		if err := binder.BindQueryParams(req, &dest); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBindQueryParams_CaseInsensitive(b *testing.B) {
+	benchmarkBindQueryParams(b, NewBinder(), benchQuery)
+}
+
+func BenchmarkBindQueryParams_CaseSensitive(b *testing.B) {
+	benchmarkBindQueryParams(b, NewBinder(WithCaseSensitive(true)), strings.ToLower(benchQuery))
+}
+
+// BenchmarkLookup_FoldedIndex and BenchmarkLookup_EqualFoldScan compare lookup of every field of benchFields in the
+// folded key index with scanning all keys with strings.EqualFold per field
+func BenchmarkLookup_FoldedIndex(b *testing.B) {
+	data := benchData(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		folded := foldKeys(data)
+		for name := range data {
+			if _, ok := lookupFolded(data, folded, strings.ToLower(name)); !ok {
+				b.Fatal(name)
+			}
+		}
+	}
+}
+
+func BenchmarkLookup_EqualFoldScan(b *testing.B) {
+	data := benchData(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for name := range data {
+			name = strings.ToLower(name)
+			found := false
+			for k := range data {
+				if strings.EqualFold(k, name) {
+					found = true
+				}
+			}
+			if !found {
+				b.Fatal(name)
+			}
+		}
+	}
+}
+
+// benchData returns query of benchQuery
+func benchData(b *testing.B) map[string][]string {
+	u, err := url.Parse(benchQuery)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return u.Query()
+}
+
+func TestBindBody_MaxBodySize(t *testing.T) {
+	const limit = 32
+	testCases := []struct {
+		name        string
+		contentType string
+		body        func(size int) string
+	}{
+		{name: "json", contentType: MIMEApplicationJSON, body: func(size int) string {
+			return `{"name":"` + strings.Repeat("a", size-len(`{"name":""}`)) + `"}`
+		}},
+		{name: "xml", contentType: MIMEApplicationXML, body: func(size int) string {
+			return `<a><name>` + strings.Repeat("a", size-len(`<a><name></name></a>`)) + `</name></a>`
+		}},
+		{name: "form", contentType: MIMEApplicationForm, body: func(size int) string {
+			return "name=" + strings.Repeat("a", size-len("name="))
+		}},
+	}
+	for _, tc := range testCases {
+		for _, size := range []int{limit - 1, limit, limit + 1} {
+			for _, chunked := range []bool{false, true} {
+				t.Run(fmt.Sprintf("%s %d chunked %v", tc.name, size, chunked), func(t *testing.T) {
+					body := tc.body(size)
+					if len(body) != size {
+						t.Fatalf("body has %d bytes, want %d", len(body), size)
+					}
+					req := newRequest(http.MethodPost, "/", tc.contentType, body)
+					if chunked {
+						// unknown length is only caught while reading
+						req.ContentLength = -1
+					}
+					var dest struct {
+						Name string `json:"name" xml:"name" form:"name"`
+					}
+					err := NewBinder(WithMaxBodySize(limit)).BindBody(req, &dest)
+					if size > limit {
+						if !errors.Is(err, ErrBodyTooLarge) {
+							t.Errorf("error = %v, want ErrBodyTooLarge", err)
+						}
+						return
+					}
+					if err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					}
+					if len(dest.Name) == 0 {
+						t.Error("name not bound")
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestBindBody_StrictJSON(t *testing.T) {
+	type dest struct {
+		A int `json:"a"`
+		B int `json:"b"`
+	}
+	testCases := []struct {
+		name       string
+		body       string
+		wantB      int
+		wantStrict string
+	}{
+		{name: "known fields", body: `{"a":1,"b":2}`, wantB: 2},
+		{name: "trailing whitespace", body: "{\"a\":1}\n\t "},
+		{name: "unknown field", body: `{"a":1,"c":3}`, wantStrict: `unknown field "c"`},
+		{name: "concatenated", body: `{"a":1}{"b":2}`, wantStrict: "unexpected data after top-level value"},
+		{name: "trailing garbage", body: `{"a":1} x`, wantStrict: "unexpected data after top-level value"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var lenient dest
+			if err := BindBody(newRequest(http.MethodPost, "/", MIMEApplicationJSON, tc.body), &lenient); err != nil {
+				t.Errorf("lenient binder error: %v", err)
+			}
+			// lenient binder ignores unknown fields and decodes only the first value
+			if lenient.A != 1 || lenient.B != tc.wantB {
+				t.Errorf("lenient binder got %+v", lenient)
+			}
+
+			var strict dest
+			err := NewBinder(WithStrictJSON(true)).BindBody(newRequest(http.MethodPost, "/", MIMEApplicationJSON, tc.body), &strict)
+			if tc.wantStrict == "" {
+				if err != nil {
+					t.Errorf("strict binder error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantStrict) {
+				t.Errorf("strict binder error = %v, want it to contain %q", err, tc.wantStrict)
+			}
+		})
+	}
+}
+
+func TestBindForm_QueryDoesNotLeak(t *testing.T) {
+	type dest struct {
+		Name  string   `form:"name"`
+		Page  int      `form:"page"`
+		Tags  []string `form:"tag"`
+		Extra string   `form:"extra"`
+	}
+	testCases := []struct {
+		name string
+		req  func(t *testing.T) *http.Request
+		bind func(r *http.Request, i interface{}) error
+	}{
+		{name: "urlencoded", bind: BindForm, req: func(t *testing.T) *http.Request {
+			return newRequest(http.MethodPost, "/?name=q&page=9&tag=q&extra=q", MIMEApplicationForm, "name=f&tag=f")
+		}},
+		{name: "urlencoded without content type", bind: BindForm, req: func(t *testing.T) *http.Request {
+			return newRequest(http.MethodPost, "/?name=q&page=9&tag=q&extra=q", "", "name=f&tag=f")
+		}},
+		{name: "multipart", bind: BindMultipartForm, req: func(t *testing.T) *http.Request {
+			return newMultipartRequest(t, "/?name=q&page=9&tag=q&extra=q", []string{"name", "f", "tag", "f"}, nil)
+		}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var d dest
+			if err := tc.bind(tc.req(t), &d); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if want := (dest{Name: "f", Tags: []string{"f"}}); !reflect.DeepEqual(d, want) {
+				t.Errorf("got %+v, want %+v", d, want)
+			}
+		})
+	}
+}
+
+func TestBindQueryParams_Arrays(t *testing.T) {
+	type dest struct {
+		Point  [2]float64 `query:"point"`
+		IDs    [3]int     `query:"id"`
+		Names  *[2]string `query:"name"`
+		Absent [2]int     `query:"absent"`
+	}
+	var d dest
+	query := "/?point=1.5&point=2&id=7&name=a&name=b&name=c"
+	if err := BindQueryParams(newRequest(http.MethodGet, query, "", ""), &d); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := dest{Point: [2]float64{1.5, 2}, IDs: [3]int{7}, Names: &[2]string{"a", "b"}}
+	if !reflect.DeepEqual(d, want) {
+		t.Errorf("got %+v, want %+v", d, want)
+	}
+}
+
+func TestBindQueryParams_ArrayInvalidElement(t *testing.T) {
+	var dest struct {
+		IDs [2]int `query:"id"`
+	}
+	err := BindQueryParams(newRequest(http.MethodGet, "/?id=1&id=x", "", ""), &dest)
+	var ne *strconv.NumError
+	if !errors.As(err, &ne) || ne.Num != "x" {
+		t.Fatalf("expected NumError of x, got %v", err)
+	}
+}
+
+func TestBindQueryParams_BracketNotation(t *testing.T) {
+	type dest struct {
+		Items  []string            `query:"items"`
+		Filter map[string]string   `query:"filter"`
+		IDs    map[string][]int    `query:"ids"`
+		Nums   []int               `query:"nums"`
+		Meta   map[string][]string `query:"meta"`
+	}
+	testCases := []struct {
+		name  string
+		query string
+		want  dest
+	}{
+		{name: "indexed slice ordered by index", query: "items[1]=b&items[0]=a&items[10]=c",
+			want: dest{Items: []string{"a", "b", "c"}}},
+		{name: "gaps dropped", query: "items[0]=a&items[5]=b", want: dest{Items: []string{"a", "b"}}},
+		{name: "empty brackets keep order", query: "items[]=b&items[]=a", want: dest{Items: []string{"b", "a"}}},
+		{name: "bare values first", query: "items[0]=b&items=a", want: dest{Items: []string{"a", "b"}}},
+		{name: "indexed ints", query: "nums[1]=2&nums[0]=1", want: dest{Nums: []int{1, 2}}},
+		{name: "map", query: "filter[status]=open&filter[type]=x",
+			want: dest{Filter: map[string]string{"status": "open", "type": "x"}}},
+		{name: "map dot notation", query: "filter.status=open", want: dest{Filter: map[string]string{"status": "open"}}},
+		{name: "map deeper levels kept in key", query: "filter[a][b]=c", want: dest{Filter: map[string]string{"a[b]": "c"}}},
+		{name: "map of slices", query: "ids[a]=1&ids[a]=2&meta[t]=x&meta[t]=y",
+			want: dest{IDs: map[string][]int{"a": {1, 2}}, Meta: map[string][]string{"t": {"x", "y"}}}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var d dest
+			if err := BindQueryParams(newRequest(http.MethodGet, "/?"+tc.query, "", ""), &d); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(d, tc.want) {
+				t.Errorf("got %+v, want %+v", d, tc.want)
+			}
+		})
+	}
+}
+
+func TestBindBody_UnsupportedMediaType(t *testing.T) {
+	testCases := []struct {
+		name        string
+		contentType string
+		wantMissing bool
+	}{
+		{name: "unknown", contentType: "application/x-unknown; charset=utf-8"},
+		{name: "missing", contentType: "", wantMissing: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var dest struct {
+				Name string `json:"name"`
+			}
+			err := BindBody(newRequest(http.MethodPost, "/", tc.contentType, `{"name":"n"}`), &dest)
+			if !errors.Is(err, ErrUnsupportedMediaType) {
+				t.Fatalf("error = %v, want ErrUnsupportedMediaType", err)
+			}
+			var mt *UnsupportedMediaTypeError
+			if !errors.As(err, &mt) || mt.ContentType != tc.contentType {
+				t.Fatalf("error = %#v, want UnsupportedMediaTypeError with %q", err, tc.contentType)
+			}
+			if errors.Is(err, ErrMissingContentType) != tc.wantMissing {
+				t.Errorf("errors.Is(err, ErrMissingContentType) = %v, want %v", !tc.wantMissing, tc.wantMissing)
+			}
+		})
+	}
+}
+
+func TestBindBody_ContentTypeNotAllowed(t *testing.T) {
+	b := &DefaultBinder{AllowedContentTypes: []string{MIMEApplicationJSON}}
+	var dest struct {
+		Name string `json:"name" form:"name" query:"name"`
+	}
+	req := newRequest(http.MethodPost, "/?name=q", MIMEApplicationForm, "name=n")
+	err := b.Bind(&dest, req)
+	var mt *UnsupportedMediaTypeError
+	if !errors.As(err, &mt) || !errors.Is(err, ErrUnsupportedMediaType) || mt.ContentType != MIMEApplicationForm {
+		t.Fatalf("error = %v, want UnsupportedMediaTypeError", err)
+	}
+	if dest.Name != "" {
+		t.Errorf("query bound despite rejected body: %q", dest.Name)
+	}
+	if err := b.BindBody(newRequest(http.MethodPost, "/", MIMEApplicationJSON, `{"name":"n"}`), &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestBindBody_JSONUseNumber(t *testing.T) {
+	const body = `{"id":1234567890123456789,"nested":{"id":9223372036854775807},"price":1.5}`
+
+	var lenient map[string]interface{}
+	if err := BindBody(newRequest(http.MethodPost, "/", MIMEApplicationJSON, body), &lenient); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := lenient["id"].(float64); !ok {
+		t.Errorf("id = %T, want float64 by default", lenient["id"])
+	}
+
+	b := NewBinder(WithJSONUseNumber(true))
+	var m map[string]interface{}
+	if err := b.BindBody(newRequest(http.MethodPost, "/", MIMEApplicationJSON, body), &m); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m["id"] != json.Number("1234567890123456789") {
+		t.Errorf("id = %#v, want json.Number preserving all digits", m["id"])
+	}
+	if nested := m["nested"].(map[string]interface{}); nested["id"] != json.Number("9223372036854775807") {
+		t.Errorf("nested id = %#v", nested["id"])
+	}
+	if m["price"] != json.Number("1.5") {
+		t.Errorf("price = %#v", m["price"])
+	}
+
+	var dest struct {
+		ID    interface{} `json:"id"`
+		Price float64     `json:"price"`
+	}
+	if err := b.BindBody(newRequest(http.MethodPost, "/", MIMEApplicationJSON, body), &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n, ok := dest.ID.(json.Number); !ok || n.String() != "1234567890123456789" {
+		t.Errorf("ID = %#v", dest.ID)
+	}
+	if dest.Price != 1.5 {
+		t.Errorf("Price = %v", dest.Price)
+	}
+}
+
+func TestBindWith_SourceOrder(t *testing.T) {
+	type dest struct {
+		Name string `param:"name" query:"name" header:"X-Name" cookie:"name" json:"name"`
+		Only string `query:"only"`
+	}
+	newReq := func() *http.Request {
+		req := newRequest(http.MethodPost, "/?name=query&only=q", MIMEApplicationJSON, `{"name":"body"}`)
+		req.SetPathValue("name", "path")
+		req.Header.Set("X-Name", "header")
+		req.AddCookie(&http.Cookie{Name: "name", Value: "cookie"})
+		return req
+	}
+	testCases := []struct {
+		sources  []Source
+		want     string
+		wantOnly string
+	}{
+		{sources: []Source{SourceBody, SourceQuery}, want: "query", wantOnly: "q"},
+		{sources: []Source{SourceQuery, SourceBody}, want: "body", wantOnly: "q"},
+		{sources: []Source{SourceHeader, SourceBody}, want: "body"},
+		{sources: []Source{SourceBody, SourceHeader}, want: "header"},
+		{sources: []Source{SourceCookie, SourcePath}, want: "path"},
+		{sources: []Source{SourcePath, SourceCookie}, want: "cookie"},
+		// without sources binds like Bind, path, query and body
+		{sources: nil, want: "body", wantOnly: "q"},
+	}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprint(tc.sources), func(t *testing.T) {
+			var d dest
+			if err := BindWith(newReq(), &d, tc.sources...); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d.Name != tc.want || d.Only != tc.wantOnly {
+				t.Errorf("got %+v, want name %q only %q", d, tc.want, tc.wantOnly)
+			}
+		})
+	}
+
+	var d dest
+	if err := BindWith(newReq(), &d, Source("nowhere")); err == nil {
+		t.Error("expected error for unknown source")
+	}
+}
+
+func TestBindQueryParams_Complex(t *testing.T) {
+	var dest struct {
+		C64  complex64   `query:"c64"`
+		C128 complex128  `query:"c128"`
+		Ptr  *complex128 `query:"ptr"`
+		All  []complex64 `query:"all"`
+	}
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?c64=1%2B2i&c128=(-3.5-1i)&ptr=2i&all=1&all=1-1i", "", ""), &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dest.C64 != 1+2i || dest.C128 != -3.5-1i || dest.Ptr == nil || *dest.Ptr != 2i ||
+		!reflect.DeepEqual(dest.All, []complex64{1, 1 - 1i}) {
+		t.Errorf("got %+v", dest)
+	}
+	err := BindQueryParams(newRequest(http.MethodGet, "/?c64=1%2Bxi", "", ""), &dest)
+	var ne *strconv.NumError
+	if !errors.As(err, &ne) || ne.Num != "1+xi" {
+		t.Errorf("expected NumError of 1+xi, got %v", err)
+	}
+}
+
+func TestBindQueryParams_UnsupportedTypeNamesField(t *testing.T) {
+	testCases := []struct {
+		name string
+		dest interface{}
+		want string
+	}{
+		{name: "chan", dest: &struct {
+			Events chan int `query:"events"`
+		}{}, want: `cannot bind "query:events" into field Events of type chan int`},
+		{name: "func", dest: &struct {
+			Callback func() `query:"cb"`
+		}{}, want: `cannot bind "query:cb" into field Callback of type func()`},
+		{name: "struct without unmarshaler", dest: &struct {
+			Point struct{ X int } `query:"point" format:"none"`
+		}{}, want: `into field Point of type struct { X int }`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := BindQueryParams(newRequest(http.MethodGet, "/?events=1&cb=1&point=1", "", ""), tc.dest)
+			if !errors.Is(err, ErrUnsupportedType) || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("error = %v, want ErrUnsupportedType containing %q", err, tc.want)
+			}
+		})
+	}
+}
+
+type fieldErrorAddress struct {
+	Zip int `query:"zip" form:"zip"`
+}
+
+type fieldErrorTarget struct {
+	Age     int               `query:"age"`
+	Address fieldErrorAddress `query:"address"`
+	Score   float64           `form:"score"`
+}
+
+func TestBind_FieldError(t *testing.T) {
+	testCases := []struct {
+		name       string
+		binder     *DefaultBinder
+		target     string
+		body       string
+		all        bool
+		wantField  []string
+		wantSource []string
+		wantValue  []string
+	}{
+		{name: "fail fast names struct field", binder: NewBinder(), target: "/?age=old&address.zip=x",
+			wantField: []string{"Age"}, wantSource: []string{"query"}, wantValue: []string{"old"}},
+		{name: "fail fast names input key", binder: NewBinder(WithFieldErrorInputKeys(true)), target: "/?age=old",
+			wantField: []string{"age"}, wantSource: []string{"query"}, wantValue: []string{"old"}},
+		{name: "fail fast names nested input key", binder: NewBinder(WithFieldErrorInputKeys(true)), target: "/?address.zip=x",
+			wantField: []string{"address.zip"}, wantSource: []string{"query"}, wantValue: []string{"x"}},
+		{name: "form source", binder: NewBinder(), target: "/", body: "score=high",
+			wantField: []string{"Score"}, wantSource: []string{"form"}, wantValue: []string{"high"}},
+		{name: "accumulate names nested struct fields", binder: NewBinder(), all: true,
+			target: "/?age=old&address.zip=x", body: "score=high",
+			wantField: []string{"Age", "Address.Zip", "Score"}, wantSource: []string{"query", "query", "form"},
+			wantValue: []string{"old", "x", "high"}},
+		{name: "accumulate names input keys", binder: NewBinder(WithFieldErrorInputKeys(true)), all: true,
+			target:    "/?age=old&address.zip=x",
+			wantField: []string{"age", "address.zip"}, wantSource: []string{"query", "query"}, wantValue: []string{"old", "x"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newRequest(http.MethodPost, tc.target, MIMEApplicationForm, tc.body)
+			var dest fieldErrorTarget
+			var err error
+			if tc.all {
+				err = tc.binder.BindAll(r, &dest)
+			} else {
+				err = tc.binder.Bind(&dest, r)
+			}
+			errs := []error{err}
+			if joined, ok := err.(interface{ Unwrap() []error }); ok {
+				errs = joined.Unwrap()
+			}
+			if len(errs) != len(tc.wantField) {
+				t.Fatalf("got %d errors (%v), want %d", len(errs), err, len(tc.wantField))
+			}
+			for n, err := range errs {
+				var fe FieldError
+				if !errors.As(err, &fe) {
+					t.Fatalf("error %d = %v, want FieldError", n, err)
+				}
+				if fe.Field() != tc.wantField[n] || fe.Source() != tc.wantSource[n] || fe.Value() != tc.wantValue[n] {
+					t.Errorf("error %d: field=%q source=%q value=%q, want field=%q source=%q value=%q", n,
+						fe.Field(), fe.Source(), fe.Value(), tc.wantField[n], tc.wantSource[n], tc.wantValue[n])
+				}
+				if fe.Unwrap() == nil {
+					t.Errorf("error %d: FieldError does not wrap underlying error", n)
+				}
+			}
+		})
+	}
+}
+
+func TestBind_FieldErrorOfRequired(t *testing.T) {
+	var dest struct {
+		Name string `query:"name,required"`
+	}
+	err := NewBinder().Bind(&dest, newRequest(http.MethodGet, "/", "", ""))
+	var fe FieldError
+	if !errors.As(err, &fe) || fe.Field() != "Name" || fe.Source() != "query" {
+		t.Fatalf("expected FieldError of Name from query, got %v", err)
+	}
+	if !errors.Is(err, ErrRequired) {
+		t.Errorf("expected FieldError to wrap ErrRequired, got %v", err)
+	}
+}
+
+func TestBindQueryParams_EmptySliceClears(t *testing.T) {
+	type patch struct {
+		Tags []string `query:"tags" form:"tags"`
+		IDs  []int    `query:"ids" form:"ids"`
+	}
+	testCases := []struct {
+		name     string
+		target   string
+		clears   bool
+		wantTags []string
+		wantIDs  []int
+	}{
+		{name: "omitted stays nil", target: "/", clears: true},
+		{name: "empty clears", target: "/?tags=&ids=", clears: true, wantTags: []string{}, wantIDs: []int{}},
+		{name: "repeated empty clears", target: "/?tags=&tags=", clears: true, wantTags: []string{}},
+		{name: "populated", target: "/?tags=a&tags=b&ids=1", clears: true, wantTags: []string{"a", "b"}, wantIDs: []int{1}},
+		{name: "empty element without option", target: "/?tags=", wantTags: []string{""}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var dest patch
+			err := NewBinder(WithEmptySliceClears(tc.clears)).BindQueryParams(newRequest(http.MethodGet, tc.target, "", ""), &dest)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(dest.Tags, tc.wantTags) {
+				t.Errorf("Tags = %#v, want %#v", dest.Tags, tc.wantTags)
+			}
+			if !reflect.DeepEqual(dest.IDs, tc.wantIDs) {
+				t.Errorf("IDs = %#v, want %#v", dest.IDs, tc.wantIDs)
+			}
+		})
+	}
+}
+
+func TestBindForm_EmptySliceClears(t *testing.T) {
+	dest := struct {
+		Tags []string `form:"tags"`
+	}{Tags: []string{"old"}}
+	r := newRequest(http.MethodPatch, "/", MIMEApplicationForm, "tags=")
+	if err := NewBinder(WithEmptySliceClears(true)).BindForm(r, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dest.Tags == nil || len(dest.Tags) != 0 {
+		t.Errorf("Tags = %#v, want cleared non-nil empty slice", dest.Tags)
+	}
+}
+
+// binaryToken implements only encoding.BinaryUnmarshaler
+type binaryToken struct {
+	data []byte
+}
+
+func (t *binaryToken) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("empty token")
+	}
+	t.data = append([]byte(nil), data...)
+	return nil
+}
+
+// textAndBinaryToken implements both encoding.TextUnmarshaler and encoding.BinaryUnmarshaler
+type textAndBinaryToken struct {
+	via string
+}
+
+func (t *textAndBinaryToken) UnmarshalText([]byte) error {
+	t.via = "text"
+	return nil
+}
+
+func (t *textAndBinaryToken) UnmarshalBinary([]byte) error {
+	t.via = "binary"
+	return nil
+}
+
+func TestBindHeaders_BinaryUnmarshaler(t *testing.T) {
+	var dest struct {
+		Raw     binaryToken        `header:"X-Raw"`
+		Encoded *binaryToken       `header:"X-Encoded,base64"`
+		Both    textAndBinaryToken `header:"X-Both,base64"`
+	}
+	r := newRequest(http.MethodGet, "/", "", "")
+	r.Header.Set("X-Raw", "plain bytes")
+	r.Header.Set("X-Encoded", "AAH/Cg==")
+	r.Header.Set("X-Both", "not base64!")
+	if err := BindHeaders(r, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(dest.Raw.data) != "plain bytes" {
+		t.Errorf("Raw = %q, want raw header bytes", dest.Raw.data)
+	}
+	if dest.Encoded == nil || !bytes.Equal(dest.Encoded.data, []byte{0x00, 0x01, 0xff, 0x0a}) {
+		t.Errorf("Encoded = %v, want base64 decoded bytes", dest.Encoded)
+	}
+	if dest.Both.via != "text" {
+		t.Errorf("Both bound via %q, want TextUnmarshaler to win over BinaryUnmarshaler", dest.Both.via)
+	}
+}
+
+func TestBindCookies_BinaryUnmarshalerErrors(t *testing.T) {
+	testCases := []struct {
+		name   string
+		cookie string
+		dest   interface{}
+		want   error
+	}{
+		{name: "invalid base64", cookie: "%%%", dest: &struct {
+			Token binaryToken `cookie:"token,base64"`
+		}{}, want: ErrInvalidFormat},
+		{name: "unmarshaler error", cookie: "", dest: &struct {
+			Token binaryToken `cookie:"token"`
+		}{}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newRequest(http.MethodGet, "/", "", "")
+			r.AddCookie(&http.Cookie{Name: "token", Value: tc.cookie})
+			err := BindCookies(r, tc.dest)
+			if err == nil || tc.want != nil && !errors.Is(err, tc.want) {
+				t.Errorf("error = %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestBindQueryParams_TrimAndLower(t *testing.T) {
+	type trimmed struct {
+		Name  string   `query:"name,trim"`
+		Age   int      `query:"age,trim"`
+		Ptr   *uint    `query:"ptr,trim"`
+		OK    bool     `query:"ok,trim"`
+		Tags  []string `query:"tags,trim"`
+		IDs   []int64  `query:"ids,trim"`
+		Email string   `query:"email,trim,lower"`
+		Kind  string   `query:"kind,lower"`
+		Raw   string   `query:"raw"`
+	}
+	target := "/?name=%20alice%09&age=%2042%20&ptr=%207&ok=%20true%20&tags=%20a%20&tags=b%20&ids=%201&ids=2%20" +
+		"&email=%20Alice@Example.COM%20&kind=%20ADMIN&raw=%20as%20is%20"
+	var dest trimmed
+	if err := BindQueryParams(newRequest(http.MethodGet, target, "", ""), &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	seven := uint(7)
+	want := trimmed{Name: "alice", Age: 42, Ptr: &seven, OK: true, Tags: []string{"a", "b"}, IDs: []int64{1, 2},
+		Email: "alice@example.com", Kind: " admin", Raw: " as is "}
+	if !reflect.DeepEqual(dest, want) {
+		t.Errorf("got %+v, want %+v", dest, want)
+	}
+}
+
+func TestBindQueryParams_UntrimmedNumberFails(t *testing.T) {
+	var dest struct {
+		Age int `query:"age"`
+	}
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?age=%2042%20", "", ""), &dest); err == nil {
+		t.Errorf("expected error binding untrimmed number, got %d", dest.Age)
+	}
+	if err := NewBinder(WithTrimSpace(true)).BindQueryParams(newRequest(http.MethodGet, "/?age=%2042%20", "", ""), &dest); err != nil || dest.Age != 42 {
+		t.Errorf("WithTrimSpace: age = %d, err = %v, want 42", dest.Age, err)
+	}
+}
+
+func TestBindQueryParams_TypedMap(t *testing.T) {
+	ints := map[string]int{}
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?score=5&count=3", "", ""), &ints); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(ints, map[string]int{"score": 5, "count": 3}) {
+		t.Errorf("got %v", ints)
+	}
+
+	var slices map[string][]int
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?ids=1&ids=2&page=3", "", ""), &slices); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(slices, map[string][]int{"ids": {1, 2}, "page": {3}}) {
+		t.Errorf("got %v", slices)
+	}
+}
+
+func TestBindQueryParams_TypedMapParseError(t *testing.T) {
+	var m map[string]int
+	err := BindQueryParams(newRequest(http.MethodGet, "/?score=high", "", ""), &m)
+	var be *BindingError
+	if !errors.As(err, &be) || be.Field != "score" || !reflect.DeepEqual(be.Values, []string{"high"}) {
+		t.Fatalf("expected BindingError of score, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "field=score") || !strings.Contains(err.Error(), `"high"`) {
+		t.Errorf("error %q does not name the key and value", err)
+	}
+	var fe FieldError
+	if !errors.As(err, &fe) || fe.Field() != "score" || fe.Source() != "query" {
+		t.Errorf("expected FieldError of score from query, got %v", err)
+	}
+}
+
+type internedRecord struct {
+	Country  string `query:"country"`
+	Currency string `query:"currency"`
+}
+
+// internedQuery carries a long parameter that is not bound, unescaped values are substrings of the raw query so every
+// retained uninterned value keeps the whole query alive
+var internedQuery = "country=NO&currency=NOK&session=" + strings.Repeat("x", 256)
+
+// benchmarkStringInterner binds fresh copy of internedQuery per request (as read from the network) and retains the
+// bound records, reporting heap retained per record
+func benchmarkStringInterner(b *testing.B, binder *DefaultBinder) {
+	kept := make([]internedRecord, b.N)
+	b.ReportAllocs()
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		req := newRequest(http.MethodGet, "/?"+strings.Clone(internedQuery), "", "")
+		if err := binder.BindQueryParams(req, &kept[i]); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.StopTimer()
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	b.ReportMetric(float64(int64(after.HeapAlloc)-int64(before.HeapAlloc))/float64(b.N), "retained-B/op")
+	runtime.KeepAlive(kept)
+}
+
+func BenchmarkBindQueryParams_StringInterner(b *testing.B) {
+	interned := map[string]string{"NO": "NO", "NOK": "NOK", "SE": "SE", "SEK": "SEK"}
+	benchmarkStringInterner(b, NewBinder(WithStringInterner(func(s string) string {
+		if v, ok := interned[s]; ok {
+			return v
+		}
+		return s
+	})))
+}
+
+func BenchmarkBindQueryParams_NoStringInterner(b *testing.B) {
+	benchmarkStringInterner(b, NewBinder())
+}
+
+func TestBindQueryParams_TrimBeforeRequired(t *testing.T) {
+	testCases := []struct {
+		name     string
+		binder   *DefaultBinder
+		tag      string
+		slice    bool
+		target   string
+		wantErr  bool
+		wantName string
+	}{
+		{name: "blank rejected with trim", tag: "name,required,trim", target: "/?name=%20%20", wantErr: true},
+		{name: "option order does not matter", tag: "name,trim,required", target: "/?name=%09", wantErr: true},
+		{name: "empty rejected with trim", tag: "name,trim,required", target: "/?name=", wantErr: true},
+		{name: "blank slice elements rejected with trim", tag: "name,trim,required", slice: true,
+			target: "/?name=%20&name=", wantErr: true},
+		{name: "padded value trimmed", tag: "name,trim,required", target: "/?name=%20bob%20", wantName: "bob"},
+		{name: "blank accepted without trim", tag: "name,required", target: "/?name=%20%20", wantName: "  "},
+		{name: "nonempty rejects blank with binder TrimSpace", binder: NewBinder(WithTrimSpace(true)),
+			tag: "name,required=nonempty", target: "/?name=%20%20", wantErr: true},
+		{name: "nonempty accepts blank without trimming", tag: "name,required=nonempty", target: "/?name=%20%20",
+			wantName: "  "},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			binder := tc.binder
+			if binder == nil {
+				binder = NewBinder()
+			}
+			typ := reflect.TypeOf("")
+			if tc.slice {
+				typ = reflect.TypeOf([]string(nil))
+			}
+			dest := reflect.New(reflect.StructOf([]reflect.StructField{
+				{Name: "Name", Type: typ, Tag: reflect.StructTag(`query:"` + tc.tag + `"`)},
+			}))
+			err := binder.BindQueryParams(newRequest(http.MethodGet, tc.target, "", ""), dest.Interface())
+			if tc.wantErr {
+				if !errors.Is(err, ErrRequired) {
+					t.Errorf("error = %v, want ErrRequired", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if name := dest.Elem().Field(0).String(); name != tc.wantName {
+				t.Errorf("Name = %q, want %q", name, tc.wantName)
+			}
+		})
+	}
+}
+
+func TestBind_PointerAbsentEmptyValued(t *testing.T) {
+	type pointers struct {
+		N *int    `query:"n" form:"n"`
+		B *bool   `query:"b" form:"b"`
+		S *string `query:"s" form:"s"`
+	}
+	one, yes, word, empty := 1, true, "x", ""
+	testCases := []struct {
+		name  string
+		input string
+		want  pointers
+	}{
+		{name: "absent", input: "", want: pointers{}},
+		{name: "empty", input: "n=&b=&s=", want: pointers{S: &empty}},
+		{name: "valued", input: "n=1&b=true&s=x", want: pointers{N: &one, B: &yes, S: &word}},
+	}
+	for _, tc := range testCases {
+		t.Run("query "+tc.name, func(t *testing.T) {
+			var dest pointers
+			if err := BindQueryParams(newRequest(http.MethodGet, "/?"+tc.input, "", ""), &dest); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(dest, tc.want) {
+				t.Errorf("got %s, want %s", formatPointers(dest.N, dest.B, dest.S), formatPointers(tc.want.N, tc.want.B, tc.want.S))
+			}
+		})
+		t.Run("form "+tc.name, func(t *testing.T) {
+			var dest pointers
+			if err := BindForm(newRequest(http.MethodPost, "/", MIMEApplicationForm, tc.input), &dest); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(dest, tc.want) {
+				t.Errorf("got %s, want %s", formatPointers(dest.N, dest.B, dest.S), formatPointers(tc.want.N, tc.want.B, tc.want.S))
+			}
+		})
+	}
+}
+
+// formatPointers renders pointer fields by their values, nil ones as nil
+func formatPointers(n *int, b *bool, s *string) string {
+	out := make([]string, 0, 3)
+	for _, v := range []interface{}{n, b, s} {
+		if rv := reflect.ValueOf(v); rv.IsNil() {
+			out = append(out, "nil")
+		} else {
+			out = append(out, fmt.Sprintf("&%q", fmt.Sprint(rv.Elem().Interface())))
+		}
+	}
+	return strings.Join(out, " ")
+}
+
+func TestBindForm_CheckboxOn(t *testing.T) {
+	var dest struct {
+		Agree bool `form:"agree"`
+	}
+	if err := BindForm(newRequest(http.MethodPost, "/", MIMEApplicationForm, "agree=on"), &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !dest.Agree {
+		t.Error("Agree = false, want checked checkbox `on` to bind as true")
+	}
+}
+
+func TestBindForm_CheckboxAbsent(t *testing.T) {
+	var dest struct {
+		Name  string `form:"name"`
+		Agree bool   `form:"agree"`
+	}
+	if err := BindForm(newRequest(http.MethodPost, "/", MIMEApplicationForm, "name=n"), &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dest.Agree {
+		t.Error("Agree = true, want unchecked checkbox to leave false")
+	}
+}
+
+func TestBindForm_BoolLiterals(t *testing.T) {
+	type flags struct {
+		A bool  `form:"a"`
+		B bool  `form:"b"`
+		C bool  `form:"c"`
+		D *bool `form:"d"`
+		E bool  `form:"e"`
+	}
+	var dest flags
+	body := "a=YES&b=Off&c=1&d=no&e=true"
+	if err := BindForm(newRequest(http.MethodPost, "/", MIMEApplicationForm, body), &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !dest.A || dest.B || !dest.C || dest.D == nil || *dest.D || !dest.E {
+		t.Errorf("got %+v", dest)
+	}
+
+	custom := &DefaultBinder{BoolLiterals: map[string]bool{"ja": true}}
+	if err := custom.BindForm(newRequest(http.MethodPost, "/", MIMEApplicationForm, "a=ja"), &dest); err != nil || !dest.A {
+		t.Errorf("custom literal: A = %v, err = %v", dest.A, err)
+	}
+	if err := custom.BindForm(newRequest(http.MethodPost, "/", MIMEApplicationForm, "a=on"), &dest); err == nil {
+		t.Error("expected custom literals to replace the default ones")
+	}
+}
+
+func TestBind_DashSkipsSourceOnly(t *testing.T) {
+	type order struct {
+		Status string `query:"-" json:"status"`
+		Note   string `query:"note" json:"-"`
+		Dash   string `query:"-," json:"dash"`
+	}
+	req := newRequest(http.MethodPost, "/?status=query&note=query&-=query", MIMEApplicationJSON,
+		`{"status":"body","note":"body","dash":"body"}`)
+	var dest order
+	if err := Bind(req, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (order{Status: "body", Note: "query", Dash: "body"}); dest != want {
+		t.Errorf("got %+v, want %+v", dest, want)
+	}
+
+	dest = order{}
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?status=query&-=query", "", ""), &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (order{Dash: "query"}); dest != want {
+		t.Errorf("got %+v, want %+v", dest, want)
+	}
+}
+
+func TestBind_ErrorModes(t *testing.T) {
+	type target struct {
+		ID    int `query:"id"`
+		Page  int `query:"page"`
+		Age   int `form:"age"`
+		Count int `form:"count"`
+	}
+	testCases := []struct {
+		name   string
+		binder *DefaultBinder
+		all    bool
+		want   []string
+	}{
+		{name: "default bind fails fast", binder: NewBinder(), want: []string{"ID"}},
+		{name: "default bind all collects", binder: NewBinder(), all: true, want: []string{"ID", "Page", "Age", "Count"}},
+		// collected query errors are joined with the first body one
+		{name: "collect query", binder: NewBinder(WithErrorMode(SourceQuery, ErrorModeCollect)),
+			want: []string{"ID", "Page", "Age"}},
+		{name: "collect body", binder: NewBinder(WithErrorMode(SourceBody, ErrorModeCollect)),
+			want: []string{"ID"}},
+		{name: "collect query and body", binder: NewBinder(WithErrorMode(SourceQuery, ErrorModeCollect),
+			WithErrorMode(SourceBody, ErrorModeCollect)), want: []string{"ID", "Page", "Age", "Count"}},
+		{name: "fail fast query in bind all", binder: NewBinder(WithErrorMode(SourceQuery, ErrorModeFailFast)), all: true,
+			want: []string{"ID"}},
+		{name: "fail fast body in bind all", binder: NewBinder(WithErrorMode(SourceBody, ErrorModeFailFast)), all: true,
+			want: []string{"ID", "Page", "Age"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newRequest(http.MethodPost, "/?id=x&page=y", MIMEApplicationForm, "age=old&count=many")
+			var dest target
+			var err error
+			if tc.all {
+				err = tc.binder.BindAll(r, &dest)
+			} else {
+				err = tc.binder.Bind(&dest, r)
+			}
+			errs := []error{err}
+			if joined, ok := err.(interface{ Unwrap() []error }); ok {
+				errs = joined.Unwrap()
+			}
+			var got []string
+			for n, err := range errs {
+				var fe FieldError
+				if !errors.As(err, &fe) {
+					t.Fatalf("error %d = %v, want FieldError", n, err)
+				}
+				got = append(got, fe.Field())
+			}
+			if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+				t.Errorf("got errors of %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBind_TagNames(t *testing.T) {
+	type target struct {
+		Limit int    `query:"${LIMIT}"`
+		Token string `header:"${TOKEN_HEADER}"`
+	}
+	b := &DefaultBinder{TagNames: map[string]string{"LIMIT": "max", "TOKEN_HEADER": "X-Acme-Token"}, FieldErrorInputKeys: true}
+
+	r := newRequest(http.MethodGet, "/?max=10", "", "")
+	r.Header.Set("X-Acme-Token", "secret")
+	var dest target
+	if err := b.BindQueryParams(r, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := b.BindHeaders(r, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (target{Limit: 10, Token: "secret"}); dest != want {
+		t.Errorf("got %+v, want %+v", dest, want)
+	}
+
+	err := b.BindQueryParams(newRequest(http.MethodGet, "/?max=x", "", ""), &dest)
+	var fe FieldError
+	if !errors.As(err, &fe) {
+		t.Fatalf("got error %v, want FieldError", err)
+	}
+	if fe.Field() != "max" {
+		t.Errorf("field = %q, want substituted tag name max", fe.Field())
+	}
+
+	b.TagNames = map[string]string{"LIMIT": "max"}
+	err = b.BindHeaders(r, &dest)
+	if err == nil {
+		t.Fatal("expected error for unknown name")
+	}
+	if msg := err.Error(); !strings.Contains(msg, `"${TOKEN_HEADER}"`) || !strings.Contains(msg, `"TOKEN_HEADER"`) ||
+		strings.Contains(msg, "Token") {
+		t.Errorf("error %q should name the tag, not the Go field", msg)
+	}
+}
+
+func TestBindWithRaw(t *testing.T) {
+	type address struct {
+		City string `query:"city"`
+	}
+	type payload struct {
+		ID      int      `query:"id"`
+		Tags    []string `query:"tag"`
+		Name    string   `form:"name"`
+		Note    string   `json:"note"`
+		Address address
+	}
+	var dest payload
+	req := newRequest(http.MethodPost, "/?id=007&tag=a&tag=b&city=NYC", MIMEApplicationForm, "name=%20bob")
+	raw, err := BindWithRaw(req, &dest)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]string{"ID": "007", "Tags": "a,b", "Name": " bob", "Address.City": "NYC"}
+	if !reflect.DeepEqual(raw, want) {
+		t.Errorf("raw = %v, want %v", raw, want)
+	}
+	if dest.ID != 7 || dest.Address.City != "NYC" {
+		t.Errorf("got %+v", dest)
+	}
+
+	dest = payload{}
+	req = newRequest(http.MethodPost, "/?id=1", MIMEApplicationJSON, `{"note":"hi"}`)
+	if raw, err = BindWithRaw(req, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := map[string]string{"ID": "1"}; !reflect.DeepEqual(raw, want) {
+		t.Errorf("raw = %v, want %v (JSON body is not recorded)", raw, want)
+	}
+	if dest.Note != "hi" {
+		t.Errorf("Note = %q, want hi", dest.Note)
+	}
+}
+
+func TestBindBody_AllowedContentTypes(t *testing.T) {
+	type payload struct {
+		Name string `json:"name" form:"name"`
+	}
+	b := &DefaultBinder{AllowedContentTypes: []string{"Application/JSON"}}
+
+	var dest payload
+	req := newRequest(http.MethodPost, "/", "application/json; charset=utf-8", `{"name":"json"}`)
+	if err := b.BindBody(req, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dest.Name != "json" {
+		t.Errorf("Name = %q, want json", dest.Name)
+	}
+
+	dest = payload{}
+	if err := b.BindBody(newRequest(http.MethodPost, "/", MIMEApplicationForm, "name=form"), &dest); !errors.Is(err, ErrUnsupportedMediaType) {
+		t.Fatalf("got error %v, want ErrUnsupportedMediaType", err)
+	}
+	if dest.Name != "" {
+		t.Errorf("Name = %q, want body of disallowed type unbound", dest.Name)
+	}
+	if err := b.BindBody(newRequest(http.MethodPost, "/", MIMEApplicationForm, ""), &dest); err != nil {
+		t.Errorf("empty body: unexpected error: %v", err)
+	}
+	if err := (&DefaultBinder{}).BindBody(newRequest(http.MethodPost, "/", MIMEApplicationForm, "name=form"), &dest); err != nil {
+		t.Errorf("empty list: unexpected error: %v", err)
+	}
+	if dest.Name != "form" {
+		t.Errorf("Name = %q, want form", dest.Name)
+	}
+}
+
+func TestBindQueryParams_StringPreprocessor(t *testing.T) {
+	RegisterNormalizer("test-lower", strings.ToLower)
+	type search struct {
+		Query  string   `query:"q"`
+		Ptr    *string  `query:"ptr"`
+		Tags   []string `query:"tag"`
+		Lower  string   `query:"lower" normalize:"test-lower"`
+		Count  int      `query:"count"`
+		Absent *string  `query:"absent"`
+	}
+	b := &DefaultBinder{StringPreprocessor: strings.ToUpper}
+	req := newRequest(http.MethodGet, "/?q=go&ptr=chi&tag=a&tag=b&lower=MiXed&count=5", "", "")
+	var dest search
+	if err := b.BindQueryParams(req, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dest.Query != "GO" || dest.Ptr == nil || *dest.Ptr != "CHI" || !reflect.DeepEqual(dest.Tags, []string{"A", "B"}) {
+		t.Errorf("got %+v", dest)
+	}
+	if dest.Lower != "MIXED" {
+		t.Errorf("Lower = %q, want preprocessor applied after normalizer", dest.Lower)
+	}
+	if dest.Count != 5 || dest.Absent != nil {
+		t.Errorf("got %+v", dest)
+	}
+
+	dest = search{}
+	if err := BindQueryParams(req, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dest.Query != "go" {
+		t.Errorf("Query = %q, want unchanged without preprocessor", dest.Query)
+	}
+}
+
+func TestBindQueryParams_SliceErrorSkip(t *testing.T) {
+	type filter struct {
+		IDs []int `query:"id"`
+	}
+	var skipped []string
+	b := &DefaultBinder{
+		SliceErrorMode: SliceErrorSkip,
+		OnSkippedElement: func(field, value string, err error) {
+			if err == nil {
+				t.Errorf("%s %q: skipped without error", field, value)
+			}
+			skipped = append(skipped, field+"="+value)
+		},
+	}
+	req := newRequest(http.MethodGet, "/?id=1&id=x&id=3&id=", "", "")
+	var dest filter
+	if err := b.BindQueryParams(req, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(dest.IDs, []int{1, 3, 0}) {
+		t.Errorf("IDs = %v, want [1 3 0]", dest.IDs)
+	}
+	if want := []string{"IDs=x"}; !reflect.DeepEqual(skipped, want) {
+		t.Errorf("skipped = %v, want %v", skipped, want)
+	}
+
+	if err := (&DefaultBinder{SliceErrorMode: SliceErrorSkip}).BindQueryParams(req, &dest); err != nil {
+		t.Fatalf("without callback: unexpected error: %v", err)
+	}
+	if err := (&DefaultBinder{}).BindQueryParams(req, &dest); err == nil {
+		t.Error("expected error of invalid element by default")
+	}
+}
+
+func TestBindBearer(t *testing.T) {
+	type token struct {
+		Token  string `bearer:"token"`
+		Tenant string `bearer:"0"`
+		UserID int    `bearer:"1"`
+	}
+	bearer := func(authorization string) *http.Request {
+		req := newRequest(http.MethodGet, "/", "", "")
+		if authorization != "" {
+			req.Header.Set(HeaderAuthorization, authorization)
+		}
+		return req
+	}
+
+	var dest token
+	if err := BindBearer(bearer("Bearer acme:42:sig"), &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (token{Token: "acme:42:sig"}); dest != want {
+		t.Errorf("got %+v, want %+v", dest, want)
+	}
+
+	b := &DefaultBinder{BearerDelimiter: ":"}
+	dest = token{}
+	if err := b.BindBearer(bearer("bearer  acme:42:sig "), &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (token{Token: "acme:42:sig", Tenant: "acme", UserID: 42}); dest != want {
+		t.Errorf("got %+v, want %+v", dest, want)
+	}
+	if err := b.BindBearer(bearer("Bearer acme:x:sig"), &dest); err == nil {
+		t.Error("expected error for invalid segment")
+	}
+
+	for _, authorization := range []string{"", "Basic dXNlcjpwYXNz", "Bearer", "Bearer  "} {
+		dest = token{}
+		if err := b.BindBearer(bearer(authorization), &dest); err != nil {
+			t.Errorf("%q: unexpected error: %v", authorization, err)
+		}
+		if dest != (token{}) {
+			t.Errorf("%q: bound %+v, want nothing", authorization, dest)
+		}
+	}
+}
+
+func TestBindHeaders_TrimPrefixSuffix(t *testing.T) {
+	type headers struct {
+		OrderID int      `header:"X-Order" trimprefix:"order_"`
+		Sizes   []string `header:"X-Size" trimsuffix:"px"`
+		Both    string   `header:"X-Both" trimprefix:"<" trimsuffix:">"`
+		Plain   int      `header:"X-Plain" trimprefix:"order_"`
+		Code    string   `header:"X-Code" trimprefix:"c_"`
+	}
+	req := newRequest(http.MethodGet, "/", "", "")
+	req.Header.Set("X-Order", "order_42")
+	req.Header.Add("X-Size", "10px")
+	req.Header.Add("X-Size", "20")
+	req.Header.Set("X-Both", "<a>")
+	req.Header.Set("X-Plain", "7")
+	// preprocessor runs before the affixes are trimmed
+	req.Header.Set("X-Code", " c_x ")
+	b := &DefaultBinder{StringPreprocessor: strings.TrimSpace}
+	var dest headers
+	if err := b.BindHeaders(req, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (headers{OrderID: 42, Sizes: []string{"10", "20"}, Both: "a", Plain: 7, Code: "x"}); !reflect.DeepEqual(dest, want) {
+		t.Errorf("got %+v, want %+v", dest, want)
+	}
+	if got := req.Header.Values("X-Size"); !reflect.DeepEqual(got, []string{"10px", "20"}) {
+		t.Errorf("request header modified: %q", got)
+	}
+}
+
+func TestBindBody_GRPCWebText(t *testing.T) {
+	type message struct {
+		Data []byte
+	}
+	b := &DefaultBinder{ProtobufUnmarshaler: func(data []byte, i interface{}) error {
+		i.(*message).Data = data
+		return nil
+	}}
+	var dest message
+	req := newRequest(http.MethodPost, "/", MIMEApplicationGRPCWebText, "CgVoZWxsbxAB")
+	if err := b.BindBody(req, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []byte("\n\x05hello\x10\x01"); !bytes.Equal(dest.Data, want) {
+		t.Errorf("Data = %q, want %q", dest.Data, want)
+	}
+
+	if err := b.BindBody(newRequest(http.MethodPost, "/", MIMEApplicationGRPCWebText, "not base64!"), &dest); err == nil {
+		t.Error("expected error for malformed base64 body")
+	}
+	if err := BindBody(newRequest(http.MethodPost, "/", MIMEApplicationGRPCWebText, "CgVoZWxsbxAB"), &dest); !errors.Is(err, ErrUnsupportedMediaType) {
+		t.Errorf("without unmarshaler: got error %v, want ErrUnsupportedMediaType", err)
+	}
+}
+
+type ctxKey struct{}
+
+// productRef resolves product slug using the request context
+type productRef struct {
+	Slug        string
+	Tenant      interface{}
+	HasDeadline bool
+}
+
+func (p *productRef) UnmarshalParamContext(ctx context.Context, slug string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	_, p.HasDeadline = ctx.Deadline()
+	p.Slug, p.Tenant = slug, ctx.Value(ctxKey{})
+	return nil
+}
+
+func (p *productRef) UnmarshalParam(string) error {
+	return errors.New("UnmarshalParamContext is preferred")
+}
+
+func TestBindQueryParams_ContextBindUnmarshaler(t *testing.T) {
+	type query struct {
+		Product productRef  `query:"product"`
+		Limited *productRef `query:"limited" timeout:"1m"`
+	}
+	req := newRequest(http.MethodGet, "/?product=shoe&limited=hat", "", "")
+	req = req.WithContext(context.WithValue(req.Context(), ctxKey{}, "acme"))
+	var dest query
+	if err := BindQueryParams(req, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dest.Product != (productRef{Slug: "shoe", Tenant: "acme"}) {
+		t.Errorf("Product = %+v", dest.Product)
+	}
+	if dest.Limited == nil || *dest.Limited != (productRef{Slug: "hat", Tenant: "acme", HasDeadline: true}) {
+		t.Errorf("Limited = %+v, want context with deadline", dest.Limited)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := BindQueryParams(req.WithContext(ctx), &dest); !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, want context.Canceled", err)
+	}
+
+	var invalid struct {
+		Product productRef `query:"product" timeout:"soon"`
+	}
+	if err := BindQueryParams(req, &invalid); err == nil {
+		t.Error("expected error for invalid timeout tag")
+	}
+}
+
+// waitingRef waits for its context to be done when bound from `wait` value
+type waitingRef string
+
+func (w *waitingRef) UnmarshalParamContext(ctx context.Context, param string) error {
+	if param == "wait" {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	*w = waitingRef(param)
+	return nil
+}
+
+// slowReader returns one byte of r per read, sleeping before each
+type slowReader struct {
+	r     io.Reader
+	delay time.Duration
+}
+
+func (s slowReader) Read(p []byte) (int, error) {
+	time.Sleep(s.delay)
+	return s.r.Read(p[:1])
+}
+
+func TestBind_Timeout(t *testing.T) {
+	type payload struct {
+		Ref  waitingRef `query:"ref"`
+		Name string     `json:"name"`
+	}
+	b := &DefaultBinder{Timeout: 20 * time.Millisecond}
+
+	var dest payload
+	if err := b.Bind(&dest, newRequest(http.MethodPost, "/?ref=a", MIMEApplicationJSON, `{"name":"n"}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dest != (payload{Ref: "a", Name: "n"}) {
+		t.Errorf("got %+v", dest)
+	}
+
+	if err := b.Bind(&dest, newRequest(http.MethodGet, "/?ref=wait", "", "")); !errors.Is(err, ErrBindTimeout) {
+		t.Errorf("unmarshaler: got error %v, want ErrBindTimeout", err)
+	}
+
+	req := newRequest(http.MethodPost, "/", MIMEApplicationJSON, "")
+	req.Body = io.NopCloser(slowReader{r: strings.NewReader(`{"name":"slow"}`), delay: 5 * time.Millisecond})
+	req.ContentLength = -1
+	if err := b.Bind(&dest, req); !errors.Is(err, ErrBindTimeout) {
+		t.Errorf("body: got error %v, want ErrBindTimeout", err)
+	}
+}
+
+func TestBindQueryParams_OnErrorDefault(t *testing.T) {
+	RegisterDefaultFunc("test-seven", func(interface{}) (interface{}, error) { return 7, nil })
+	type query struct {
+		Page     int   `query:"page" onerror:"default"`
+		Limit    int   `query:"limit" onerror:"default" default:"@test-seven"`
+		IDs      []int `query:"id" onerror:"default"`
+		Strict   int   `query:"strict"`
+		Computed int   `query:"computed" default:"@test-seven"`
+	}
+	dest := query{Page: 9, IDs: []int{1}}
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?page=abc&limit=x&id=1&id=y&computed=3", "", ""), &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (query{Limit: 7, Computed: 3}); !reflect.DeepEqual(dest, want) {
+		t.Errorf("got %+v, want %+v", dest, want)
+	}
+
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?page=2&strict=x", "", ""), &dest); err == nil {
+		t.Error("expected error of field without onerror tag")
+	}
+}
+
+func TestBindQueryPairs(t *testing.T) {
+	pairs, err := BindQueryPairs(newRequest(http.MethodGet, "/?b=2&a=1&&b=%20x+y&flag&c=", "", ""))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []QueryPair{{"b", "2"}, {"a", "1"}, {"b", " x y"}, {"flag", ""}, {"c", ""}}
+	if !reflect.DeepEqual(pairs, want) {
+		t.Errorf("got %v, want %v", pairs, want)
+	}
+
+	if pairs, err := BindQueryPairs(newRequest(http.MethodGet, "/", "", "")); err != nil || len(pairs) != 0 {
+		t.Errorf("empty query: got %v, %v", pairs, err)
+	}
+	for _, target := range []string{"/?a=1;b=2", "/?a=%zz"} {
+		if _, err := BindQueryPairs(newRequest(http.MethodGet, target, "", "")); err == nil {
+			t.Errorf("%s: expected error", target)
+		}
+	}
+}
+
+func TestBindQueryParams_Join(t *testing.T) {
+	type query struct {
+		Data  []byte `query:"d" join:"" format:"base64"`
+		Path  string `query:"p" join:"/"`
+		Words string `query:"w"`
+	}
+	var dest query
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?d=aGVs&d=bG8=&p=a&p=b&p=c&w=x&w=y", "", ""), &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(dest.Data) != "hello" || dest.Path != "a/b/c" || dest.Words != "x" {
+		t.Errorf("got %+v (Data %q)", dest, dest.Data)
+	}
+	// chunks are joined before decoding
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?d=aGVs&d=!", "", ""), &dest); err == nil {
+		t.Error("expected error of malformed joined value")
+	}
+}
+
+func TestBind_OnDeprecated(t *testing.T) {
+	type query struct {
+		PageSize int    `query:"page_size"`
+		Limit    int    `query:"limit" form:"limit" deprecated:"true"`
+		Old      string `query:"old" deprecated:"false"`
+	}
+	var used []string
+	b := &DefaultBinder{OnDeprecated: func(r *http.Request, field, source, param string) {
+		if r == nil {
+			t.Error("request not passed")
+		}
+		used = append(used, field+" "+source+" "+param)
+	}}
+	req := newRequest(http.MethodPost, "/?page_size=1&limit=2&old=x", MIMEApplicationForm, "limit=3")
+	var dest query
+	if err := b.Bind(&dest, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dest != (query{PageSize: 1, Limit: 3, Old: "x"}) {
+		t.Errorf("got %+v", dest)
+	}
+	if want := []string{"Limit query limit", "Limit form limit"}; !reflect.DeepEqual(used, want) {
+		t.Errorf("reported %q, want %q", used, want)
+	}
+
+	used = nil
+	if err := b.Bind(&dest, newRequest(http.MethodGet, "/?page_size=1", "", "")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if used != nil {
+		t.Errorf("reported %q for absent deprecated field", used)
+	}
+	if err := Bind(req, &dest); err != nil {
+		t.Errorf("without callback: unexpected error: %v", err)
+	}
+}
+
+func TestBindBody_AppendBody(t *testing.T) {
+	type item struct {
+		Name string `json:"name" form:"name"`
+	}
+	b := &DefaultBinder{AppendBody: true}
+	var items []item
+	for _, req := range []*http.Request{
+		newRequest(http.MethodPost, "/", MIMEApplicationJSON, `{"name":"a"}`),
+		newRequest(http.MethodPost, "/", MIMEApplicationForm, "name=b"),
+		newRequest(http.MethodPost, "/", MIMEApplicationJSON, ""),
+	} {
+		if err := b.BindBody(req, &items); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := b.BindBody(newRequest(http.MethodPost, "/", MIMEApplicationJSON, `{"name":`), &items); err == nil {
+		t.Error("expected error of malformed body")
+	}
+	if want := []item{{"a"}, {"b"}}; !reflect.DeepEqual(items, want) {
+		t.Errorf("got %+v, want %+v", items, want)
+	}
+
+	// without AppendBody JSON array replaces the slice
+	if err := BindBody(newRequest(http.MethodPost, "/", MIMEApplicationJSON, `[{"name":"c"}]`), &items); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []item{{"c"}}; !reflect.DeepEqual(items, want) {
+		t.Errorf("got %+v, want %+v", items, want)
+	}
+}
+
+func TestBindCookies(t *testing.T) {
+	type session struct {
+		SessionID string   `cookie:"sid"`
+		Prefs     []string `cookie:"pref"`
+		Theme     string   `cookie:"theme" query:"theme"`
+		Name      string   `json:"name"`
+	}
+	req := newRequest(http.MethodPost, "/?theme=query", MIMEApplicationJSON, `{"name":"body"}`)
+	req.Header.Set("Cookie", "sid=abc; pref=a; pref=b; theme=cookie")
+
+	var dest session
+	if err := BindCookies(req, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (session{SessionID: "abc", Prefs: []string{"a", "b"}, Theme: "cookie"}); !reflect.DeepEqual(dest, want) {
+		t.Errorf("got %+v, want %+v", dest, want)
+	}
+
+	dest = session{SessionID: "kept"}
+	if err := BindCookies(newRequest(http.MethodGet, "/", "", ""), &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dest.SessionID != "kept" {
+		t.Errorf("SessionID = %q, want absent cookie to leave field untouched", dest.SessionID)
+	}
+
+	dest = session{}
+	if err := Bind(req, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (session{Theme: "query", Name: "body"}); !reflect.DeepEqual(dest, want) {
+		t.Errorf("Bind without IncludeCookies: got %+v, want %+v", dest, want)
+	}
+
+	req = newRequest(http.MethodPost, "/?theme=query", MIMEApplicationJSON, `{"name":"body"}`)
+	req.Header.Set("Cookie", "sid=abc; theme=cookie")
+	dest = session{}
+	if err := (&DefaultBinder{IncludeCookies: true}).Bind(&dest, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (session{SessionID: "abc", Theme: "cookie", Name: "body"}); !reflect.DeepEqual(dest, want) {
+		t.Errorf("Bind with IncludeCookies: got %+v, want %+v", dest, want)
+	}
+}
+
+func TestBindAll(t *testing.T) {
+	type address struct {
+		Zip int `form:"zip"`
+	}
+	type signup struct {
+		Age     int    `query:"age"`
+		Name    string `query:"name"`
+		Count   int    `query:"count"`
+		Address address
+	}
+	var dest signup
+	req := newRequest(http.MethodPost, "/?age=old&name=bob&count=x", MIMEApplicationForm, "zip=abc")
+	err := BindAll(req, &dest)
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("got error %v, want joined errors", err)
+	}
+	want := []BindingError{
+		{Field: "age", Source: "query", StructField: "Age", Values: []string{"old"}},
+		{Field: "count", Source: "query", StructField: "Count", Values: []string{"x"}},
+		{Field: "zip", Source: "form", StructField: "Address.Zip", Values: []string{"abc"}},
+	}
+	errs := joined.Unwrap()
+	if len(errs) != len(want) {
+		t.Fatalf("got %d errors (%v), want %d", len(errs), err, len(want))
+	}
+	for n, err := range errs {
+		var be *BindingError
+		if !errors.As(err, &be) {
+			t.Fatalf("error %d = %v, want BindingError", n, err)
+		}
+		if be.Field != want[n].Field || be.Source != want[n].Source || be.StructField != want[n].StructField ||
+			!reflect.DeepEqual(be.Values, want[n].Values) {
+			t.Errorf("error %d = %+v, want %+v", n, be, want[n])
+		}
+	}
+	if dest.Name != "bob" {
+		t.Errorf("Name = %q, want fields after failed one bound", dest.Name)
+	}
+
+	// body decoding error is joined after field errors
+	err = BindAll(newRequest(http.MethodPost, "/?age=old", MIMEApplicationJSON, `{"name":`), &dest)
+	joined, ok = err.(interface{ Unwrap() []error })
+	if !ok || len(joined.Unwrap()) != 2 {
+		t.Fatalf("got error %v, want field and body errors", err)
+	}
+	if err := BindAll(newRequest(http.MethodGet, "/?age=1", "", ""), &dest); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestBindBody_ConcatenatedJSON(t *testing.T) {
+	type payload struct {
+		A int    `json:"a"`
+		B int    `json:"b"`
+		C string `json:"c"`
+	}
+	body := `{"a":1,"c":"x"}` + "\n" + `{"b":2}{"a":3}`
+
+	var first payload
+	if err := BindBody(newRequest(http.MethodPost, "/", MIMEApplicationJSON, body), &first); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first != (payload{A: 1, C: "x"}) {
+		t.Errorf("only first object is decoded by default, got %+v", first)
+	}
+
+	b := &DefaultBinder{ConcatenatedJSON: true}
+	var merged payload
+	if err := b.BindBody(newRequest(http.MethodPost, "/", MIMEApplicationJSON, body), &merged); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if merged != (payload{A: 3, B: 2, C: "x"}) {
+		t.Errorf("got %+v, want objects merged in order", merged)
+	}
+
+	var bad payload
+	if err := b.BindBody(newRequest(http.MethodPost, "/", MIMEApplicationJSON, `{"a":1}{"b":`), &bad); err == nil {
+		t.Error("malformed trailing object: expected error")
+	}
+
+	strict := &DefaultBinder{ConcatenatedJSON: true, StrictJSONNull: true}
+	var dest nullPayload
+	err := strict.BindBody(newRequest(http.MethodPost, "/", MIMEApplicationJSON, `{"name":"a"}{"name":null}`), &dest)
+	if !errors.Is(err, ErrRequired) {
+		t.Errorf("null in later object: got error %v, want ErrRequired", err)
+	}
+}
+
+func TestBindQueryParams_EmptyBoolIsTrue(t *testing.T) {
+	type flags struct {
+		Verbose bool   `query:"verbose"`
+		Dry     *bool  `query:"dry"`
+		Bits    []bool `query:"bits"`
+		Off     bool   `query:"off"`
+		Absent  bool   `query:"absent"`
+		Default bool   `query:"default" default:"true"`
+	}
+	target := "/?verbose&dry=&bits=&bits=false&off=false"
+	b := &DefaultBinder{EmptyBoolIsTrue: true}
+	var f flags
+	if err := b.BindQueryParams(newRequest(http.MethodGet, target, "", ""), &f); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !f.Verbose || f.Dry == nil || !*f.Dry || !reflect.DeepEqual(f.Bits, []bool{true, false}) || f.Off ||
+		f.Absent || !f.Default {
+		t.Errorf("got %+v", f)
+	}
+
+	var d flags
+	if err := BindQueryParams(newRequest(http.MethodGet, target, "", ""), &d); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.Verbose || d.Dry != nil && *d.Dry || !reflect.DeepEqual(d.Bits, []bool{false, false}) {
+		t.Errorf("empty values bind as false by default, got %+v", d)
+	}
+
+	var strict struct {
+		Verbose bool `query:"verbose,required=nonempty"`
+	}
+	if err := b.BindQueryParams(newRequest(http.MethodGet, "/?verbose=", "", ""), &strict); !errors.Is(err, ErrRequired) {
+		t.Errorf("required=nonempty: got error %v, want ErrRequired", err)
+	}
+}
+
+func TestBindBody_CBOR(t *testing.T) {
+	// a CBOR map {"id": 7, "name": "n"}
+	body := "\xa2\x62id\x07\x64name\x61n"
+	b := &DefaultBinder{CBORUnmarshaler: func(data []byte, i interface{}) error {
+		if string(data) != body {
+			return errors.New("unexpected cbor data")
+		}
+		*i.(*readonlyAccount) = readonlyAccount{ID: 7, Name: "n"}
+		return nil
+	}}
+	dest := readonlyAccount{ID: 1}
+	if err := b.BindBody(newRequest(http.MethodPost, "/", MIMEApplicationCBOR, body), &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dest.ID != 1 || dest.Name != "n" {
+		t.Errorf("got %+v, want readonly ID preserved", dest)
+	}
+
+	if err := b.BindBody(newRequest(http.MethodPost, "/", MIMEApplicationCBOR, "\xa0"), &dest); err == nil {
+		t.Error("expected unmarshaler error")
+	}
+	if err := BindBody(newRequest(http.MethodPost, "/", MIMEApplicationCBOR, body), &dest); !errors.Is(err, ErrUnsupportedMediaType) {
+		t.Errorf("without unmarshaler: got error %v, want ErrUnsupportedMediaType", err)
+	}
+}
+
+// sortKeys converts all values of the key itself
+type sortKeys struct{ Keys []string }
+
+func (s *sortKeys) UnmarshalParams(params []string) error {
+	s.Keys = params
+	return nil
+}
+
+func TestBindQueryParams_MultiValueScalarPolicy(t *testing.T) {
+	type query struct {
+		Sort  string   `query:"sort"`
+		Page  *int     `query:"page"`
+		Tags  []string `query:"tags"`
+		Order sortKeys `query:"order"`
+	}
+	const target = "/?sort=a&sort=b&page=1&page=2&tags=x&tags=y&order=name&order=date"
+	testCases := []struct {
+		policy   MultiValueScalarPolicy
+		wantSort string
+		wantPage int
+	}{
+		{MultiValueFirstWins, "a", 1},
+		{MultiValueLastWins, "b", 2},
+	}
+	for _, tc := range testCases {
+		var q query
+		b := NewBinder(WithMultiValueScalarPolicy(tc.policy))
+		if err := b.BindQueryParams(newRequest(http.MethodGet, target, "", ""), &q); err != nil {
+			t.Fatalf("policy %d: unexpected error: %v", tc.policy, err)
+		}
+		if q.Sort != tc.wantSort || q.Page == nil || *q.Page != tc.wantPage || !reflect.DeepEqual(q.Tags, []string{"x", "y"}) ||
+			!reflect.DeepEqual(q.Order.Keys, []string{"name", "date"}) {
+			t.Errorf("policy %d: got %+v", tc.policy, q)
+		}
+	}
+
+	b := NewBinder(WithMultiValueScalarPolicy(MultiValueError))
+	var q query
+	if err := b.BindQueryParams(newRequest(http.MethodGet, "/?sort=a&sort=b", "", ""), &q); !errors.Is(err, ErrMultipleValues) {
+		t.Errorf("MultiValueError: got error %v, want ErrMultipleValues", err)
+	}
+	q = query{}
+	if err := b.BindQueryParams(newRequest(http.MethodGet, "/?sort=a&tags=x&tags=y&order=a&order=b", "", ""), &q); err != nil {
+		t.Errorf("MultiValueError with single values: unexpected error: %v", err)
+	}
+}
+
+func TestBind_EmptyBoolPolicies(t *testing.T) {
+	type flags struct {
+		Verbose bool  `query:"verbose"`
+		Debug   *bool `header:"X-Debug"`
+		Agree   bool  `form:"agree"`
+	}
+	bind := func(b *DefaultBinder, target, contentType, body string) (flags, error) {
+		req := newRequest(http.MethodPost, target, contentType, body)
+		req.Header.Set("X-Debug", "")
+		var f flags
+		if err := b.BindQueryParams(req, &f); err != nil {
+			return f, err
+		}
+		if err := b.BindHeaders(req, &f); err != nil {
+			return f, err
+		}
+		return f, b.BindBody(req, &f)
+	}
+
+	b := NewBinder(
+		WithEmptyBoolPolicy(SourceQuery, EmptyBoolTrue),
+		WithEmptyBoolPolicy(SourceHeader, EmptyBoolTrue),
+	)
+	f, err := bind(b, "/?verbose", MIMEApplicationForm, "agree=")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !f.Verbose || f.Debug == nil || !*f.Debug || f.Agree {
+		t.Errorf("got %+v", f)
+	}
+
+	b = NewBinder(WithEmptyBoolPolicy(SourceBody, EmptyBoolError))
+	if _, err := bind(b, "/?verbose", MIMEApplicationForm, "agree="); !errors.Is(err, ErrEmptyBool) {
+		t.Errorf("EmptyBoolError for form: got error %v, want ErrEmptyBool", err)
+	}
+
+	// policy of the source beats EmptyBoolIsTrue
+	b = &DefaultBinder{EmptyBoolIsTrue: true, EmptyBoolPolicies: map[Source]EmptyBoolPolicy{SourceQuery: EmptyBoolFalse}}
+	f, err = bind(b, "/?verbose=", MIMEApplicationForm, "agree=")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if f.Verbose || f.Debug == nil || !*f.Debug || !f.Agree {
+		t.Errorf("got %+v", f)
+	}
+}
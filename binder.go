@@ -72,7 +72,11 @@ type BindingError struct {
 	// Field is the field name where value binding failed
 	Field string `json:"field"`
 	// Values of parameter that failed to bind.
-	Values        []string `json:"-"`
+	Values []string `json:"-"`
+	// Source is the tag of source the values were read from i.e. `query`, empty when not known
+	Source string `json:"source,omitempty"`
+	// StructField is the path of struct field the values were bound into i.e. `Address.City`, empty when not known
+	StructField   string `json:"-"`
 	Message       string
 	InternalError error
 }
@@ -92,6 +96,41 @@ func (be *BindingError) Error() string {
 	return fmt.Sprintf("%s, field=%s", be.Message, be.Field)
 }
 
+// Unwrap returns internal error so errors.Is/As can match it i.e. ErrRequired
+func (be *BindingError) Unwrap() error {
+	return be.InternalError
+}
+
+// FieldError is implemented by errors of fields failing to bind, returned by Bind and other struct binding functions
+// (and joined by BindAll), so they can be rendered uniformly i.e. as `{"errors":[{"field":"age","message":"..."}]}`.
+// Use errors.As to get it, the underlying error (i.e. *BindingError or ErrRequired) is available with errors.As/Is too.
+type FieldError interface {
+	error
+	// Field is path of the struct field i.e. `Address.City`, or the input key i.e. `address.city` with
+	// DefaultBinder.FieldErrorInputKeys
+	Field() string
+	// Source is the tag of source the values were read from i.e. `query`
+	Source() string
+	// Value is the input value, multiple values joined with comma
+	Value() string
+	// Unwrap returns the underlying error
+	Unwrap() error
+}
+
+// fieldError implements FieldError
+type fieldError struct {
+	field  string
+	source string
+	values []string
+	err    error
+}
+
+func (fe *fieldError) Error() string  { return fe.err.Error() }
+func (fe *fieldError) Field() string  { return fe.field }
+func (fe *fieldError) Source() string { return fe.source }
+func (fe *fieldError) Value() string  { return strings.Join(fe.values, ",") }
+func (fe *fieldError) Unwrap() error  { return fe.err }
+
 // ValueBinder provides utility methods for binding query or path parameter to various Go built-in types
 type ValueBinder struct {
 	// failFast is flag for binding methods to return without attempting to bind when previous binding already failed
@@ -0,0 +1,137 @@
+package binding
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// ErrNonCanonicalJSON is returned for JSON bodies that are not canonical when DefaultBinder.CanonicalJSON is set
+var ErrNonCanonicalJSON = errors.New("json body is not canonical")
+
+// checkCanonicalJSON checks that data is single JSON value equal byte for byte to its canonical form
+func checkCanonicalJSON(data []byte) error {
+	canonical, err := canonicalJSON(data)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(data, canonical) {
+		i := 0
+		for i < len(data) && i < len(canonical) && data[i] == canonical[i] {
+			i++
+		}
+		return fmt.Errorf("%w: differs from canonical form at offset %d", ErrNonCanonicalJSON, i)
+	}
+	return nil
+}
+
+// canonicalJSON returns canonical form of single JSON value data: no insignificant white space, object keys sorted in
+// ascending order of their (unescaped) UTF-8 bytes, strings escaped like encoding/json does without HTML escaping and
+// numbers written as encoding/json writes float64 (shortest form that reads back, exponent only below 1e-6 and from
+// 1e21, `-0` as `0`). Duplicate keys fail with ErrNonCanonicalJSON as they have no canonical form.
+func canonicalJSON(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var buf bytes.Buffer
+	if err := writeCanonicalJSON(&buf, decoder); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		if err == nil {
+			err = fmt.Errorf("%w: data after top-level value", ErrNonCanonicalJSON)
+		}
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeCanonicalJSON reads next JSON value from decoder and writes its canonical form to buf
+func writeCanonicalJSON(buf *bytes.Buffer, decoder *json.Decoder) error {
+	tok, err := decoder.Token()
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	switch tok := tok.(type) {
+	case json.Delim:
+		if tok == '[' {
+			buf.WriteByte('[')
+			for n := 0; decoder.More(); n++ {
+				if n > 0 {
+					buf.WriteByte(',')
+				}
+				if err = writeCanonicalJSON(buf, decoder); err != nil {
+					return err
+				}
+			}
+			buf.WriteByte(']')
+		} else {
+			members := map[string][]byte{}
+			for decoder.More() {
+				keyTok, err := decoder.Token()
+				if err != nil {
+					return err
+				}
+				key := keyTok.(string)
+				if _, ok := members[key]; ok {
+					return fmt.Errorf("%w: duplicate key %q", ErrNonCanonicalJSON, key)
+				}
+				var member bytes.Buffer
+				if err = writeCanonicalJSON(&member, decoder); err != nil {
+					return err
+				}
+				members[key] = member.Bytes()
+			}
+			keys := make([]string, 0, len(members))
+			for key := range members {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			buf.WriteByte('{')
+			for n, key := range keys {
+				if n > 0 {
+					buf.WriteByte(',')
+				}
+				writeCanonicalString(buf, key)
+				buf.WriteByte(':')
+				buf.Write(members[key])
+			}
+			buf.WriteByte('}')
+		}
+		// closing delimiter
+		_, err = decoder.Token()
+		return err
+	case string:
+		writeCanonicalString(buf, tok)
+	case json.Number:
+		f, err := strconv.ParseFloat(string(tok), 64)
+		if err != nil {
+			return fmt.Errorf("%w: number %s: %w", ErrNonCanonicalJSON, tok, err)
+		}
+		if f == 0 {
+			f = 0 // -0
+		}
+		b, _ := json.Marshal(f)
+		buf.Write(b)
+	case bool:
+		buf.WriteString(strconv.FormatBool(tok))
+	case nil:
+		buf.WriteString("null")
+	}
+	return nil
+}
+
+// writeCanonicalString writes s as JSON string escaped by encoding/json without HTML escaping
+func writeCanonicalString(buf *bytes.Buffer, s string) {
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(s)
+	buf.Write(bytes.TrimSuffix(b.Bytes(), []byte("\n")))
+}
@@ -0,0 +1,82 @@
+package binding
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestCanonicalJSON_EquivalentDocuments(t *testing.T) {
+	const want = `{"a":[1,100,0.5,-2,0,1e+21,1e-7],"b":{"x":"A<&>","y":null},"c":true}`
+	docs := []string{
+		want,
+		// key order
+		`{"c":true,"b":{"y":null,"x":"A<&>"},"a":[1,100,0.5,-2,0,1e+21,1e-7]}`,
+		// white space
+		" {\n\t\"a\" : [ 1 , 100 , 0.5 , -2 , 0 , 1e+21 , 1e-7 ] ,\r\n \"b\" : { \"x\" : \"A<&>\" , \"y\" : null } , \"c\" : true }\n",
+		// number forms
+		`{"a":[1.0,1e2,0.50,-2.000,-0,1000000000000000000000,0.0000001],"b":{"x":"A<&>","y":null},"c":true}`,
+		// string escapes
+		`{"a":[1,100,0.5,-2,0,1e+21,1e-7],"b":{"x":"\u0041\u003c\u0026\u003e","y":null},"c":true}`,
+	}
+	for _, doc := range docs {
+		got, err := canonicalJSON([]byte(doc))
+		if err != nil {
+			t.Errorf("canonicalJSON(%q): %v", doc, err)
+			continue
+		}
+		if string(got) != want {
+			t.Errorf("canonicalJSON(%q) = %s, want %s", doc, got, want)
+		}
+	}
+}
+
+func TestCanonicalJSON_Invalid(t *testing.T) {
+	for _, doc := range []string{`{"a":1,"a":2}`, `{"a":1}{}`, `{"a":`, `{"a":1e400}`} {
+		if _, err := canonicalJSON([]byte(doc)); err == nil {
+			t.Errorf("canonicalJSON(%q) succeeded, want error", doc)
+		}
+	}
+}
+
+func TestBindBody_CanonicalJSON(t *testing.T) {
+	type payload struct {
+		Amount float64 `json:"amount"`
+		To     string  `json:"to"`
+	}
+	b := NewBinder(WithCanonicalJSON(true))
+
+	var p payload
+	req := newRequest(http.MethodPost, "/", "application/json", `{"amount":10.5,"to":"bob"}`)
+	if err := b.BindBody(req, &p); err != nil {
+		t.Fatalf("canonical body: %v", err)
+	}
+	if p.Amount != 10.5 || p.To != "bob" {
+		t.Errorf("got %+v", p)
+	}
+
+	for _, body := range []string{
+		`{"to":"bob","amount":10.5}`,
+		`{"amount": 10.5,"to":"bob"}`,
+		`{"amount":10.50,"to":"bob"}`,
+		`{"amount":10.5,"to":"\u0062ob"}`,
+		`{"amount":10.5,"to":"bob"}` + "\n",
+		`{"amount":10.5,"amount":11,"to":"bob"}`,
+	} {
+		var p payload
+		req := newRequest(http.MethodPost, "/", "application/json", body)
+		if err := b.BindBody(req, &p); !errors.Is(err, ErrNonCanonicalJSON) {
+			t.Errorf("body %q: got error %v, want ErrNonCanonicalJSON", body, err)
+		}
+		if p != (payload{}) {
+			t.Errorf("body %q: bound %+v before rejecting", body, p)
+		}
+	}
+
+	// off by default
+	var q payload
+	req = newRequest(http.MethodPost, "/", "application/json", `{"to":"bob","amount":10.50}`)
+	if err := NewBinder().BindBody(req, &q); err != nil {
+		t.Fatalf("non-canonical body without option: %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+package binding
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// clampField limits numeric field value into range of `clamp:"<min>,<max>"` tag. Values out of range are silently
+// replaced by the nearest bound, only malformed tag is an error.
+func clampField(spec string, field reflect.Value) error {
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return nil
+		}
+		field = field.Elem()
+	}
+	minStr, maxStr, ok := strings.Cut(spec, ",")
+	if !ok {
+		return fmt.Errorf("invalid clamp range %q, expected `min,max`", spec)
+	}
+	minStr, maxStr = strings.TrimSpace(minStr), strings.TrimSpace(maxStr)
+
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		lo, errLo := strconv.ParseInt(minStr, 10, 64)
+		hi, errHi := strconv.ParseInt(maxStr, 10, 64)
+		if errLo != nil || errHi != nil || lo > hi {
+			return fmt.Errorf("invalid clamp range %q for field of type %v", spec, field.Type())
+		}
+		field.SetInt(min(max(field.Int(), lo), hi))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		lo, errLo := strconv.ParseUint(minStr, 10, 64)
+		hi, errHi := strconv.ParseUint(maxStr, 10, 64)
+		if errLo != nil || errHi != nil || lo > hi {
+			return fmt.Errorf("invalid clamp range %q for field of type %v", spec, field.Type())
+		}
+		field.SetUint(min(max(field.Uint(), lo), hi))
+	case reflect.Float32, reflect.Float64:
+		lo, errLo := strconv.ParseFloat(minStr, 64)
+		hi, errHi := strconv.ParseFloat(maxStr, 64)
+		if errLo != nil || errHi != nil || lo > hi {
+			return fmt.Errorf("invalid clamp range %q for field of type %v", spec, field.Type())
+		}
+		field.SetFloat(min(max(field.Float(), lo), hi))
+	default:
+		return fmt.Errorf("clamp is not supported for field of type %v", field.Type())
+	}
+	return nil
+}
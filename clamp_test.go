@@ -0,0 +1,47 @@
+package binding
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestBindQueryParams_Clamp(t *testing.T) {
+	type query struct {
+		Opacity int      `query:"opacity" clamp:"0,100"`
+		Offset  int      `query:"offset" clamp:"-10, 10"`
+		Size    uint8    `query:"size" clamp:"1,64"`
+		Ratio   *float64 `query:"ratio" clamp:"0,1"`
+		Absent  *int     `query:"absent" clamp:"0,1"`
+		InRange int      `query:"in" clamp:"0,100"`
+	}
+	var dest query
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?opacity=150&offset=-50&size=0&ratio=1.5&in=42", "", ""), &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dest.Opacity != 100 || dest.Offset != -10 || dest.Size != 1 || dest.Ratio == nil || *dest.Ratio != 1 ||
+		dest.Absent != nil || dest.InRange != 42 {
+		t.Errorf("got %+v", dest)
+	}
+}
+
+func TestBindQueryParams_ClampInvalidTag(t *testing.T) {
+	testCases := []interface{}{
+		&struct {
+			V int `query:"v" clamp:"10"`
+		}{},
+		&struct {
+			V int `query:"v" clamp:"10,1"`
+		}{},
+		&struct {
+			V uint `query:"v" clamp:"-1,1"`
+		}{},
+		&struct {
+			V string `query:"v" clamp:"0,1"`
+		}{},
+	}
+	for _, dest := range testCases {
+		if err := BindQueryParams(newRequest(http.MethodGet, "/?v=5", "", ""), dest); err == nil {
+			t.Errorf("%T: expected error", dest)
+		}
+	}
+}
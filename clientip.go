@@ -0,0 +1,78 @@
+package binding
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"reflect"
+	"strings"
+)
+
+var (
+	netIPType     = reflect.TypeOf(net.IP{})
+	netipAddrType = reflect.TypeOf(netip.Addr{})
+)
+
+// formatClientIP binds client IP from `X-Forwarded-For` chain i.e. `client, proxy1, proxy2` into net.IP, netip.Addr or
+// string field. Chain including the connection address (r.RemoteAddr) is walked from the right skipping addresses of
+// DefaultBinder.TrustedProxies, the first untrusted one is the client. Without the header connection address is used.
+func formatClientIP(s *bindState, in fieldInput, field reflect.Value) error {
+	var chain []string
+	for _, v := range in.values {
+		for _, hop := range strings.Split(v, ",") {
+			if hop = strings.TrimSpace(hop); hop != "" {
+				chain = append(chain, hop)
+			}
+		}
+	}
+	chain = append(chain, s.req.RemoteAddr)
+
+	var client netip.Addr
+	for i := len(chain) - 1; i >= 0; i-- {
+		addr, err := parseHopAddr(chain[i])
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+		}
+		client = addr
+		if !s.binder.trustedProxy(addr) {
+			break
+		}
+	}
+
+	field = allocPointer(field)
+	switch field.Type() {
+	case netipAddrType:
+		field.Set(reflect.ValueOf(client))
+	case netIPType:
+		field.Set(reflect.ValueOf(net.IP(client.AsSlice())))
+	default:
+		if field.Kind() != reflect.String {
+			return fmt.Errorf("client-ip value can not be bound into field of type %v", field.Type())
+		}
+		field.SetString(client.String())
+	}
+	return nil
+}
+
+// parseHopAddr parses address of X-Forwarded-For hop or RemoteAddr, which may have port and brackets around IPv6
+func parseHopAddr(hop string) (netip.Addr, error) {
+	if addr, err := netip.ParseAddr(hop); err == nil {
+		return addr.Unmap(), nil
+	}
+	if addrPort, err := netip.ParseAddrPort(hop); err == nil {
+		return addrPort.Addr().Unmap(), nil
+	}
+	if addr, err := netip.ParseAddr(strings.TrimSuffix(strings.TrimPrefix(hop, "["), "]")); err == nil {
+		return addr.Unmap(), nil
+	}
+	return netip.Addr{}, fmt.Errorf("malformed forwarded address %q", hop)
+}
+
+func (b *DefaultBinder) trustedProxy(addr netip.Addr) bool {
+	for _, prefix := range b.TrustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
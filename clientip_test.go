@@ -0,0 +1,62 @@
+package binding
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/netip"
+	"testing"
+)
+
+func TestBind_ClientIPWithoutHeaders(t *testing.T) {
+	var dest struct {
+		IP string `header:"X-Forwarded-For" format:"client-ip"`
+	}
+	req := newRequest(http.MethodGet, "/", "", "")
+	req.Header = http.Header{}
+	req.RemoteAddr = "192.0.2.1:1234"
+	if err := NewBinder(WithSources(SourceHeader)).Bind(&dest, req); err != nil {
+		t.Fatal(err)
+	}
+	if dest.IP != "192.0.2.1" {
+		t.Fatalf("expected connection address, got %q", dest.IP)
+	}
+}
+
+func TestBindHeaders_ClientIPTrustedProxies(t *testing.T) {
+	var dest struct {
+		Addr netip.Addr `header:"X-Forwarded-For" format:"client-ip"`
+		IP   net.IP     `header:"X-Forwarded-For" format:"client-ip"`
+	}
+	req := newRequest(http.MethodGet, "/", "", "")
+	req.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.2")
+	req.RemoteAddr = "10.0.0.1:443"
+	b := &DefaultBinder{TrustedProxies: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}}
+	if err := b.BindHeaders(req, &dest); err != nil {
+		t.Fatal(err)
+	}
+	if dest.Addr.String() != "203.0.113.7" || dest.IP.String() != "203.0.113.7" {
+		t.Fatalf("expected first untrusted hop, got %v %v", dest.Addr, dest.IP)
+	}
+
+	// without trusted proxies the connection address is the client
+	if err := BindHeaders(req, &dest); err != nil {
+		t.Fatal(err)
+	}
+	if dest.Addr.String() != "10.0.0.1" {
+		t.Fatalf("expected connection address, got %v", dest.Addr)
+	}
+}
+
+func TestBindHeaders_ClientIPMalformedHop(t *testing.T) {
+	var dest struct {
+		IP string `header:"X-Forwarded-For" format:"client-ip"`
+	}
+	req := newRequest(http.MethodGet, "/", "", "")
+	req.Header.Set("X-Forwarded-For", "not-an-ip")
+	req.RemoteAddr = "10.0.0.1:443"
+	b := &DefaultBinder{TrustedProxies: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}}
+	if err := b.BindHeaders(req, &dest); !errors.Is(err, ErrInvalidFormat) {
+		t.Fatalf("expected ErrInvalidFormat, got %v", err)
+	}
+}
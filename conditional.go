@@ -0,0 +1,20 @@
+package binding
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// switchedName returns input name of field tagged `switch:"<header>:<name>"` for the request. When request header
+// value is true (as parsed by strconv.ParseBool) the field binds from name, otherwise from its source tag name.
+func (s *bindState) switchedName(spec, name string) (string, error) {
+	header, switched, ok := strings.Cut(spec, ":")
+	if !ok || header == "" || switched == "" {
+		return "", fmt.Errorf("invalid switch %q, expected `<header>:<name>`", spec)
+	}
+	if on, _ := strconv.ParseBool(strings.TrimSpace(s.req.Header.Get(header))); on {
+		return switched, nil
+	}
+	return name, nil
+}
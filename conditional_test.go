@@ -0,0 +1,66 @@
+package binding
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type switchedSearch struct {
+	Query string `query:"q,required" switch:"X-Use-New-Param:search"`
+	Page  int    `query:"page" switch:"X-Use-New-Param:p"`
+}
+
+func TestBindQueryParams_HeaderSwitchedName(t *testing.T) {
+	testCases := []struct {
+		name    string
+		header  string
+		target  string
+		want    switchedSearch
+		wantErr string
+	}{
+		{name: "switched and missing", header: "true", target: "/?q=legacy", wantErr: "field=search"},
+		{name: "switched and present", header: "true", target: "/?q=legacy&search=new&p=2&page=9",
+			want: switchedSearch{Query: "new", Page: 2}},
+		{name: "switched by 1", header: "1", target: "/?search=new", want: switchedSearch{Query: "new"}},
+		{name: "not switched", target: "/?q=legacy&page=3", want: switchedSearch{Query: "legacy", Page: 3}},
+		{name: "not switched ignores new names", header: "false", target: "/?q=legacy&search=new&p=2",
+			want: switchedSearch{Query: "legacy"}},
+		{name: "not switched requires legacy name", header: "no", target: "/?search=new", wantErr: "field=q"},
+		{name: "unparsable header is not switched", header: "maybe", target: "/?q=legacy",
+			want: switchedSearch{Query: "legacy"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := newRequest(http.MethodGet, tc.target, "", "")
+			if tc.header != "" {
+				req.Header.Set("X-Use-New-Param", tc.header)
+			}
+			var dest switchedSearch
+			err := BindQueryParams(req, &dest)
+			if tc.wantErr != "" {
+				if !errors.Is(err, ErrRequired) || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Errorf("error = %v, want required error of %s", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if dest != tc.want {
+				t.Errorf("got %+v, want %+v", dest, tc.want)
+			}
+		})
+	}
+}
+
+func TestBindQueryParams_InvalidSwitch(t *testing.T) {
+	var dest struct {
+		Query string `query:"q" switch:"X-Use-New-Param"`
+	}
+	err := BindQueryParams(newRequest(http.MethodGet, "/?q=a", "", ""), &dest)
+	if err == nil || !strings.Contains(err.Error(), "invalid switch") {
+		t.Errorf("error = %v, want invalid switch error", err)
+	}
+}
@@ -0,0 +1,82 @@
+package binding
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrConflictingSources is matched by errors of fields set to different values by multiple sources when
+// DefaultBinder.RejectConflicts is set
+var ErrConflictingSources = errors.New("conflicting values from multiple sources")
+
+// boundValue records field value set by a source for conflict detection
+type boundValue struct {
+	source string
+	key    string
+	// value is snapshot of the field after the source set it, field is the field itself
+	value reflect.Value
+	field reflect.Value
+}
+
+// recordBound records value field at path was set to by source, failing when another source set it to a different
+// value before
+func (s *bindState) recordBound(source, key, path string, field reflect.Value) error {
+	if !s.binder.RejectConflicts {
+		return nil
+	}
+	if prev, ok := s.bound[path]; ok && prev.source != source && !reflect.DeepEqual(prev.value.Interface(), field.Interface()) {
+		return fmt.Errorf("%w: %s set by %s and %s", ErrConflictingSources, path, prev.source, source)
+	}
+	if s.bound == nil {
+		s.bound = map[string]boundValue{}
+	}
+	s.bound[path] = boundValue{source: source, key: key, value: snapshot(field), field: field}
+	return nil
+}
+
+// checkBodyConflicts fails when body decoding changed field set by another source before. JSON and XML decoders only
+// set fields present in the body, so changed value means body sent a different one.
+func (s *bindState) checkBodyConflicts() error {
+	for path, prev := range s.bound {
+		if !reflect.DeepEqual(prev.value.Interface(), prev.field.Interface()) {
+			err := fmt.Errorf("%w: %s set by %s and %s", ErrConflictingSources, path, prev.source, SourceBody)
+			if err = s.fieldError(string(SourceBody), prev.key, path, nil, err); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// snapshot returns copy of v not sharing pointees, slice elements and map entries with it, so that decoders reusing
+// them in place do not change the copy
+func snapshot(v reflect.Value) reflect.Value {
+	c := reflect.New(v.Type()).Elem()
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			p := reflect.New(v.Type().Elem())
+			p.Elem().Set(snapshot(v.Elem()))
+			c.Set(p)
+		}
+	case reflect.Slice:
+		if !v.IsNil() {
+			c.Set(reflect.MakeSlice(v.Type(), v.Len(), v.Len()))
+			for i := 0; i < v.Len(); i++ {
+				c.Index(i).Set(snapshot(v.Index(i)))
+			}
+		}
+	case reflect.Map:
+		if !v.IsNil() {
+			c.Set(reflect.MakeMapWithSize(v.Type(), v.Len()))
+			iter := v.MapRange()
+			for iter.Next() {
+				c.SetMapIndex(iter.Key(), snapshot(iter.Value()))
+			}
+		}
+	default:
+		c.Set(v)
+	}
+	return c
+}
@@ -0,0 +1,50 @@
+package binding
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestBind_RejectConflicts(t *testing.T) {
+	type dest struct {
+		Name string   `query:"name" header:"X-Name" json:"name"`
+		Tags []string `query:"tags" json:"tags"`
+		Page int      `query:"page"`
+	}
+	b := NewBinder(WithRejectConflicts(true), WithSources(SourceQuery, SourceHeader, SourceBody))
+	testCases := []struct {
+		name     string
+		target   string
+		header   string
+		body     string
+		conflict bool
+	}{
+		{"single source", "/?name=a&page=2", "", `{}`, false},
+		{"same value", "/?name=a&tags=x&tags=y", "a", `{"name":"a","tags":["x","y"]}`, false},
+		{"query and header", "/?name=a", "b", `{}`, true},
+		{"query and body", "/?name=a", "", `{"name":"b"}`, true},
+		{"slice in body", "/?tags=x", "", `{"tags":["x","y"]}`, true},
+	}
+	for _, tc := range testCases {
+		req := newRequest(http.MethodPost, tc.target, MIMEApplicationJSON, tc.body)
+		if tc.header != "" {
+			req.Header.Set("X-Name", tc.header)
+		}
+		var d dest
+		err := b.Bind(&d, req)
+		if tc.conflict != errors.Is(err, ErrConflictingSources) {
+			t.Errorf("%s: got error %v, want conflict %v", tc.name, err, tc.conflict)
+		}
+		if !tc.conflict && err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+		}
+	}
+
+	// later sources override earlier ones by default
+	var d dest
+	req := newRequest(http.MethodPost, "/?name=a", MIMEApplicationJSON, `{"name":"b"}`)
+	if err := NewBinder().Bind(&d, req); err != nil || d.Name != "b" {
+		t.Errorf("got %+v, error %v", d, err)
+	}
+}
@@ -0,0 +1,65 @@
+package binding
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Converter converts input values of a field into value of the type it is registered for
+type Converter func(values []string) (reflect.Value, error)
+
+var (
+	convertersMu sync.RWMutex
+	converters   = map[reflect.Type]Converter{}
+)
+
+// RegisterConverter registers converter for fields of type typ, i.e. types from other packages that can not implement
+// BindUnmarshaler:
+//
+//	binding.RegisterConverter(reflect.TypeOf(uuid.UUID{}), func(values []string) (reflect.Value, error) {
+//		id, err := uuid.Parse(values[0])
+//		return reflect.ValueOf(id), err
+//	})
+//
+// Converter is used for fields of the type and pointers to it after the unmarshaler interfaces are checked, it
+// receives all input values of the field. Elements of slice fields are converted one by one unless the slice type has
+// a converter of its own.
+func RegisterConverter(typ reflect.Type, fn Converter) {
+	convertersMu.Lock()
+	defer convertersMu.Unlock()
+	converters[typ] = fn
+}
+
+func lookupConverter(typ reflect.Type) (Converter, bool) {
+	convertersMu.RLock()
+	defer convertersMu.RUnlock()
+	fn, ok := converters[typ]
+	return fn, ok
+}
+
+// convertField converts values into field (allocating pointer fields) with converter registered for field type and
+// reports whether there is one
+func convertField(values []string, field reflect.Value) (bool, error) {
+	fn, ok := lookupConverter(field.Type())
+	if !ok && field.Kind() == reflect.Ptr {
+		if fn, ok = lookupConverter(field.Type().Elem()); ok {
+			field = allocPointer(field)
+		}
+	}
+	if !ok {
+		return false, nil
+	}
+	v, err := fn(values)
+	if err != nil {
+		return true, err
+	}
+	if !v.IsValid() {
+		return true, fmt.Errorf("converter of %v returned invalid value", field.Type())
+	}
+	if !v.Type().AssignableTo(field.Type()) {
+		return true, fmt.Errorf("converter of %v returned value of type %v", field.Type(), v.Type())
+	}
+	field.Set(v)
+	return true, nil
+}
@@ -0,0 +1,123 @@
+package binding
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// testUUID stands for uuid.UUID of a third-party package, an array type without unmarshaler methods
+type testUUID [16]byte
+
+func parseTestUUID(s string) (testUUID, error) {
+	var id testUUID
+	b, err := hex.DecodeString(strings.ReplaceAll(s, "-", ""))
+	if err != nil || len(b) != len(id) {
+		return id, fmt.Errorf("invalid UUID %q", s)
+	}
+	copy(id[:], b)
+	return id, nil
+}
+
+// testMoney joins all its input values, so it shows converter receives all of them
+type testMoney string
+
+// testCode has both a converter and an unmarshaler
+type testCode string
+
+func (c *testCode) UnmarshalParam(param string) error {
+	*c = testCode("unmarshaler:" + param)
+	return nil
+}
+
+func init() {
+	RegisterConverter(reflect.TypeOf(testUUID{}), func(values []string) (reflect.Value, error) {
+		id, err := parseTestUUID(values[0])
+		return reflect.ValueOf(id), err
+	})
+	RegisterConverter(reflect.TypeOf(testMoney("")), func(values []string) (reflect.Value, error) {
+		return reflect.ValueOf(testMoney(strings.Join(values, "+"))), nil
+	})
+	RegisterConverter(reflect.TypeOf(testCode("")), func(values []string) (reflect.Value, error) {
+		return reflect.ValueOf(testCode("converter:" + values[0])), nil
+	})
+}
+
+const testUUIDString = "123e4567-e89b-12d3-a456-426614174000"
+
+func TestRegisterConverter_PathParam(t *testing.T) {
+	type dest struct {
+		ID    testUUID  `param:"id"`
+		Owner *testUUID `param:"owner"`
+	}
+	req := newRequest(http.MethodGet, "/", "", "")
+	req.SetPathValue("id", testUUIDString)
+	req.SetPathValue("owner", testUUIDString)
+	var d dest
+	if err := BindPathParams(req, &d); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want, _ := parseTestUUID(testUUIDString)
+	if d.ID != want || d.Owner == nil || *d.Owner != want {
+		t.Errorf("got %+v, want %x", d, want)
+	}
+
+	req.SetPathValue("id", "not-a-uuid")
+	err := BindPathParams(req, &d)
+	if err == nil {
+		t.Error("expected error of invalid UUID")
+	}
+}
+
+func TestRegisterConverter_MultipleValues(t *testing.T) {
+	type dest struct {
+		IDs   []testUUID `query:"id"`
+		Price testMoney  `query:"price"`
+		Code  testCode   `query:"code"`
+	}
+	other := "00000000-0000-0000-0000-000000000001"
+	var d dest
+	query := "/?id=" + testUUIDString + "&id=" + other + "&price=1&price=2&code=c"
+	if err := BindQueryParams(newRequest(http.MethodGet, query, "", ""), &d); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	first, _ := parseTestUUID(testUUIDString)
+	second, _ := parseTestUUID(other)
+	if !reflect.DeepEqual(d.IDs, []testUUID{first, second}) {
+		t.Errorf("IDs = %x", d.IDs)
+	}
+	if d.Price != "1+2" {
+		t.Errorf("Price = %q, want all values", d.Price)
+	}
+	if d.Code != "unmarshaler:c" {
+		t.Errorf("Code = %q, want unmarshaler to win over converter", d.Code)
+	}
+}
+
+func TestRegisterConverter_Concurrent(t *testing.T) {
+	type local string
+	var wg sync.WaitGroup
+	for n := 0; n < 8; n++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			RegisterConverter(reflect.TypeOf(local("")), func(values []string) (reflect.Value, error) {
+				return reflect.ValueOf(local(values[0])), nil
+			})
+		}()
+		go func() {
+			defer wg.Done()
+			var d struct {
+				ID testUUID `query:"id"`
+			}
+			if err := BindQueryParams(newRequest(http.MethodGet, "/?id="+testUUIDString, "", ""), &d); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+}
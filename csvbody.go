@@ -0,0 +1,166 @@
+package binding
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// ErrUnknownColumn is matched by errors of CSV bodies with columns not mapped to any field when
+// DefaultBinder.CSVRejectUnknownColumns is set
+var ErrUnknownColumn = errors.New("unknown csv column")
+
+// CSVRowError is error of a single CSV record, Line is its line number in the body (header is line 1)
+type CSVRowError struct {
+	Line int
+	Err  error
+}
+
+// Error returns error message
+func (e *CSVRowError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+// Unwrap returns the error of the record
+func (e *CSVRowError) Unwrap() error {
+	return e.Err
+}
+
+// BindCSV binds `text/csv` body, i.e. spreadsheet upload of admin bulk-import tool, into slice pointed to by items
+// (i.e. `*[]Row` or `*[]*Row`). First record is the header, columns are mapped to fields by `csv` tags of the header
+// names in any order, so columns can be reordered, and every other record binds into one element with the regular
+// conversion rules. Columns of fields tagged `csv:"<name>,required"` must be present in the header, other missing
+// columns leave their fields untouched (or apply their `default` tag) and columns not mapped to any field are ignored
+// unless DefaultBinder.CSVRejectUnknownColumns is set. Records can have fewer cells than the header, missing and
+// empty cells are treated as missing values of the record.
+//
+// Elements are finished like those of BindBatch. Records failing to bind do not stop binding of the others, their
+// errors are *CSVRowError with line number, combined by errors.Join. Elements of failed records are left zero. Empty
+// body binds nothing.
+func BindCSV(r *http.Request, items interface{}) error {
+	return defaultBinder.BindCSV(r, items)
+}
+
+// BindCSV binds CSV body with header into slice. See package level BindCSV.
+func (b *DefaultBinder) BindCSV(r *http.Request, items interface{}) error {
+	slice, err := sliceDestination(items)
+	if err != nil {
+		return err
+	}
+	isCSV := func(mediaType string) bool { return mediaType == MIMETextCSV }
+	return b.bindElements(r, isCSV, func(s *bindState) error { return b.decodeCSV(r.Body, s, slice) })
+}
+
+func (b *DefaultBinder) decodeCSV(body io.Reader, s *bindState, slice reflect.Value) error {
+	elemType := slice.Type().Elem()
+	isPtr := elemType.Kind() == reflect.Ptr
+	if isPtr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return errors.New("binding element must be a pointer to slice of structs")
+	}
+
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := b.checkCSVHeader(header, elemType); err != nil {
+		return &CSVRowError{Line: 1, Err: err}
+	}
+
+	result := reflect.MakeSlice(slice.Type(), 0, 0)
+	var errs []error
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// malformed quoting leaves reader out of sync, following records can not be trusted
+			errs = append(errs, err)
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		data := make(map[string][]string, len(header))
+		for i, value := range record {
+			// empty cells are missing values, so they apply defaults and fail required fields
+			if i < len(header) && value != "" {
+				data[header[i]] = append(data[header[i]], value)
+			}
+		}
+		elem := reflect.New(elemType)
+		if err := b.bindCSVRecord(s, elem.Interface(), data); err != nil {
+			errs = append(errs, &CSVRowError{Line: line, Err: err})
+			elem = reflect.New(elemType)
+		}
+		if isPtr {
+			result = reflect.Append(result, elem)
+		} else {
+			result = reflect.Append(result, elem.Elem())
+		}
+	}
+	slice.Set(result)
+	return errors.Join(errs...)
+}
+
+func (b *DefaultBinder) bindCSVRecord(s *bindState, elem interface{}, data map[string][]string) error {
+	s.resetElement()
+	if err := bindData(s, "", elem, data, "csv"); err != nil {
+		return err
+	}
+	return b.finishElement(s, elem)
+}
+
+// checkCSVHeader checks that header has columns of all required fields of typ and with
+// DefaultBinder.CSVRejectUnknownColumns that all its columns are mapped to fields
+func (b *DefaultBinder) checkCSVHeader(header []string, typ reflect.Type) error {
+	columns := make(map[string][]string, len(header))
+	for _, name := range header {
+		columns[name] = nil
+	}
+	known := map[string]bool{}
+	for _, fp := range planFor(typ, "csv", b.nameTag("csv"), false).fields {
+		name, err := b.substituteNames(fp.name)
+		if err != nil {
+			return err
+		}
+		if name == "" {
+			continue
+		}
+		known[b.columnKey(name)] = true
+		if fp.opts.contains("required") && !hasKey(columns, name, b.CaseSensitive) {
+			return &BindingError{
+				Field:         name,
+				Values:        []string{},
+				Message:       "required column is missing",
+				InternalError: ErrRequired,
+			}
+		}
+	}
+	if b.CSVRejectUnknownColumns {
+		for _, name := range header {
+			if !known[b.columnKey(name)] {
+				return fmt.Errorf("%w %q", ErrUnknownColumn, name)
+			}
+		}
+	}
+	return nil
+}
+
+// columnKey returns key header column name is matched by, folded unless DefaultBinder.CaseSensitive
+func (b *DefaultBinder) columnKey(name string) string {
+	if b.CaseSensitive {
+		return name
+	}
+	return strings.ToLower(name)
+}
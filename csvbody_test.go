@@ -0,0 +1,115 @@
+package binding
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+type csvRow struct {
+	Email string `csv:"email,required"`
+	Age   int    `csv:"age"`
+}
+
+func TestBindCSV(t *testing.T) {
+	body := "age,email,extra\n30,a@example.com,x\n,b@example.com,y\n"
+	var rows []csvRow
+	if err := BindCSV(newRequest(http.MethodPost, "/", MIMETextCSV, body), &rows); err != nil {
+		t.Fatal(err)
+	}
+	want := []csvRow{{Email: "a@example.com", Age: 30}, {Email: "b@example.com"}}
+	if !reflect.DeepEqual(rows, want) {
+		t.Fatalf("expected %+v, got %+v", want, rows)
+	}
+}
+
+func TestBindCSV_RowErrorLines(t *testing.T) {
+	body := "email,age\na@example.com,1\nb@example.com,abc\nc@example.com,3\n,4\n"
+	var rows []*csvRow
+	err := BindCSV(newRequest(http.MethodPost, "/", MIMETextCSV, body), &rows)
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("expected joined row errors, got %v", err)
+	}
+	var lines []int
+	for _, err := range joined.Unwrap() {
+		var rowErr *CSVRowError
+		if !errors.As(err, &rowErr) {
+			t.Fatalf("expected CSVRowError, got %v", err)
+		}
+		lines = append(lines, rowErr.Line)
+	}
+	if !reflect.DeepEqual(lines, []int{3, 5}) {
+		t.Fatalf("expected errors of lines 3 and 5, got %v", lines)
+	}
+	if !errors.Is(joined.Unwrap()[1], ErrRequired) {
+		t.Fatalf("expected empty required cell to match ErrRequired, got %v", joined.Unwrap()[1])
+	}
+	if len(rows) != 4 || rows[0].Age != 1 || *rows[1] != (csvRow{}) || rows[2].Age != 3 {
+		t.Fatalf("unexpected rows %+v %+v %+v", rows[0], rows[1], rows[2])
+	}
+}
+
+func TestBindCSV_MissingRequiredColumn(t *testing.T) {
+	var rows []csvRow
+	err := BindCSV(newRequest(http.MethodPost, "/", MIMETextCSV, "age\n1\n"), &rows)
+	var rowErr *CSVRowError
+	if !errors.As(err, &rowErr) || rowErr.Line != 1 || !errors.Is(err, ErrRequired) {
+		t.Fatalf("expected required error of header line, got %v", err)
+	}
+}
+
+func TestBindCSV_RejectUnknownColumns(t *testing.T) {
+	var rows []csvRow
+	err := NewBinder(WithCSVRejectUnknownColumns(true)).BindCSV(newRequest(http.MethodPost, "/", MIMETextCSV, "email,x\na,1\n"), &rows)
+	if !errors.Is(err, ErrUnknownColumn) {
+		t.Fatalf("expected ErrUnknownColumn, got %v", err)
+	}
+}
+
+func TestBindCSV_TemplatesPerRecord(t *testing.T) {
+	type row struct {
+		First string `csv:"first"`
+		Last  string `csv:"last"`
+		Full  string `csv:"full" template:"{first} {last}"`
+	}
+	var rows []row
+	if err := BindCSV(newRequest(http.MethodPost, "/", MIMETextCSV, "first,last,full\nA,B,X\nC,D\nE,F,\n"), &rows); err != nil {
+		t.Fatal(err)
+	}
+	want := []row{{"A", "B", "X"}, {"C", "D", "C D"}, {"E", "F", "E F"}}
+	if !reflect.DeepEqual(rows, want) {
+		t.Fatalf("expected %+v, got %+v", want, rows)
+	}
+}
+
+func TestBindCSV_GroupsPerRecord(t *testing.T) {
+	type row struct {
+		Email string `csv:"email" group:"contact"`
+		Phone string `csv:"phone" group:"contact"`
+	}
+	var rows []row
+	if err := BindCSV(newRequest(http.MethodPost, "/", MIMETextCSV, "email,phone\na@example.com,\n,123\n"), &rows); err != nil {
+		t.Fatal(err)
+	}
+	err := BindCSV(newRequest(http.MethodPost, "/", MIMETextCSV, "email,phone\na@example.com,123\n"), &rows)
+	var rowErr *CSVRowError
+	if !errors.As(err, &rowErr) || rowErr.Line != 2 || !errors.Is(err, ErrFieldGroup) {
+		t.Fatalf("expected group error of line 2, got %v", err)
+	}
+}
+
+func TestBindCSV_ReadonlyColumn(t *testing.T) {
+	type row struct {
+		Email string `csv:"email"`
+		Role  string `csv:"role" readonly:"body"`
+	}
+	var rows []row
+	if err := BindCSV(newRequest(http.MethodPost, "/", MIMETextCSV, "email,role\na@example.com,admin\n"), &rows); err != nil {
+		t.Fatal(err)
+	}
+	if want := []row{{Email: "a@example.com"}}; !reflect.DeepEqual(rows, want) {
+		t.Fatalf("expected %+v, got %+v", want, rows)
+	}
+}
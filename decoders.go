@@ -0,0 +1,75 @@
+package binding
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Decoder decodes request body into i
+type Decoder func(r io.Reader, i interface{}) error
+
+// bodyDecoder decodes request body into i, builtin decoders rely on binder configuration and bind state
+type bodyDecoder func(b *DefaultBinder, r *http.Request, i interface{}, s *bindState) error
+
+var (
+	decodersMu sync.RWMutex
+	// decoders are body decoders keyed by media type
+	decoders = map[string]bodyDecoder{
+		MIMEApplicationJSON:        (*DefaultBinder).decodeJSONBody,
+		MIMEApplicationXML:         (*DefaultBinder).decodeXMLBody,
+		MIMETextXML:                (*DefaultBinder).decodeXMLBody,
+		MIMEApplicationGRPCWebText: (*DefaultBinder).decodeGRPCWebTextBody,
+		MIMEApplicationCBOR:        (*DefaultBinder).decodeCBORBody,
+		MIMEApplicationForm: func(b *DefaultBinder, r *http.Request, i interface{}, s *bindState) error {
+			return b.bindForm(r, i, s, false)
+		},
+		MIMEMultipartForm: func(b *DefaultBinder, r *http.Request, i interface{}, s *bindState) error {
+			return b.bindForm(r, i, s, true)
+		},
+	}
+)
+
+// RegisterDecoder registers body decoder for media type, i.e. `application/yaml`, replacing any decoder registered for
+// it before including the builtin JSON, XML, form and gRPC-Web text ones. Nil fn removes the registration so bodies of
+// that type fail with ErrUnsupportedMediaType. Fields readonly for body are preserved like for builtin decoders.
+func RegisterDecoder(mediaType string, fn Decoder) {
+	decodersMu.Lock()
+	defer decodersMu.Unlock()
+	mediaType = strings.ToLower(mediaType)
+	if fn == nil {
+		delete(decoders, mediaType)
+		return
+	}
+	decoders[mediaType] = func(_ *DefaultBinder, r *http.Request, i interface{}, _ *bindState) error {
+		defer preserveReadonly(i)()
+		return fn(r.Body, i)
+	}
+}
+
+// lookupDecoder returns decoder for Content-Type header value. Media type (without parameters like `charset`) is
+// matched exactly first, then registered types it starts with are tried, longest first, so i.e.
+// `application/json-patch+json` is still decoded as JSON unless it has a decoder of its own.
+func lookupDecoder(cType string) (bodyDecoder, bool) {
+	mediaType, _, err := mime.ParseMediaType(cType)
+	if err != nil {
+		mediaType, _, _ = strings.Cut(cType, ";")
+		mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+	}
+
+	decodersMu.RLock()
+	defer decodersMu.RUnlock()
+	if decoder, ok := decoders[mediaType]; ok {
+		return decoder, true
+	}
+	var match string
+	var decoder bodyDecoder
+	for registered, fn := range decoders {
+		if strings.HasPrefix(mediaType, registered) && len(registered) > len(match) {
+			match, decoder = registered, fn
+		}
+	}
+	return decoder, decoder != nil
+}
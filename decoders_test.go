@@ -0,0 +1,136 @@
+package binding
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// decodeKeyValues is a trivial decoder of `key: value` lines into map[string]string
+func decodeKeyValues(r io.Reader, i interface{}) error {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	dest := i.(*map[string]string)
+	*dest = map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(string(body)), "\n") {
+		k, v, ok := strings.Cut(line, ":")
+		if !ok {
+			return errors.New("missing colon")
+		}
+		(*dest)[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return nil
+}
+
+func TestRegisterDecoder(t *testing.T) {
+	RegisterDecoder("application/yaml", decodeKeyValues)
+	defer RegisterDecoder("application/yaml", nil)
+
+	for _, contentType := range []string{"application/yaml", "application/yaml; charset=utf-8", "application/yaml-stream"} {
+		t.Run(contentType, func(t *testing.T) {
+			var dest map[string]string
+			req := newRequest(http.MethodPost, "/", contentType, "name: n\nkind: k\n")
+			if err := BindBody(req, &dest); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if dest["name"] != "n" || dest["kind"] != "k" {
+				t.Errorf("got %v", dest)
+			}
+		})
+	}
+
+	var dest map[string]string
+	if err := BindBody(newRequest(http.MethodPost, "/", "application/yaml", "broken"), &dest); err == nil {
+		t.Error("expected decoder error")
+	}
+}
+
+func TestRegisterDecoder_Remove(t *testing.T) {
+	RegisterDecoder("application/x-kv", decodeKeyValues)
+	RegisterDecoder("application/x-kv", nil)
+
+	var dest map[string]string
+	err := BindBody(newRequest(http.MethodPost, "/", "application/x-kv", "a: b"), &dest)
+	if !errors.Is(err, ErrUnsupportedMediaType) {
+		t.Errorf("error = %v, want ErrUnsupportedMediaType", err)
+	}
+}
+
+func TestRegisterDecoder_PrefixOfBuiltin(t *testing.T) {
+	var dest struct {
+		Op string `json:"op"`
+	}
+	req := newRequest(http.MethodPost, "/", "application/json-patch+json", `{"op":"add"}`)
+	if err := BindBody(req, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dest.Op != "add" {
+		t.Errorf("got %+v", dest)
+	}
+}
+
+func TestRegisterDecoder_Concurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for n := 0; n < 8; n++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			RegisterDecoder("application/x-concurrent", decodeKeyValues)
+		}()
+		go func() {
+			defer wg.Done()
+			var dest map[string]string
+			_ = BindBody(newRequest(http.MethodPost, "/", MIMEApplicationJSON, `{"a":"b"}`), &dest)
+			if dest["a"] != "b" {
+				t.Errorf("got %v", dest)
+			}
+		}()
+	}
+	wg.Wait()
+	RegisterDecoder("application/x-concurrent", nil)
+}
+
+func TestRegisterDecoder_MediaTypeCaseInsensitive(t *testing.T) {
+	RegisterDecoder("Application/X-KV", decodeKeyValues)
+	defer RegisterDecoder("application/x-kv", nil)
+
+	for _, contentType := range []string{"application/x-kv", "APPLICATION/X-KV ; charset=utf-8"} {
+		var dest map[string]string
+		if err := BindBody(newRequest(http.MethodPost, "/", contentType, "a: b"), &dest); err != nil {
+			t.Fatalf("%s: unexpected error: %v", contentType, err)
+		}
+		if dest["a"] != "b" {
+			t.Errorf("%s: got %v", contentType, dest)
+		}
+	}
+}
+
+func TestRegisterDecoder_ReplaceBuiltin(t *testing.T) {
+	builtin := decoders[MIMEApplicationJSON]
+	defer func() {
+		decodersMu.Lock()
+		decoders[MIMEApplicationJSON] = builtin
+		decodersMu.Unlock()
+	}()
+
+	RegisterDecoder(MIMEApplicationJSON, decodeKeyValues)
+	var dest map[string]string
+	err := BindBody(newRequest(http.MethodPost, "/", MIMEApplicationJSON, "a: b"), &dest)
+	RegisterDecoder(MIMEApplicationJSON, nil)
+	if err != nil || dest["a"] != "b" {
+		t.Errorf("replaced JSON decoder: got %v, error %v", dest, err)
+	}
+
+	var removed struct {
+		A string `json:"a"`
+	}
+	err = BindBody(newRequest(http.MethodPost, "/", MIMEApplicationJSON, `{"a":"b"}`), &removed)
+	if !errors.Is(err, ErrUnsupportedMediaType) {
+		t.Errorf("removed JSON decoder: got error %v, want ErrUnsupportedMediaType", err)
+	}
+}
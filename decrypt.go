@@ -0,0 +1,49 @@
+package binding
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrDecryptionFailed is matched by errors returned when decryptor of field `decrypt` tag fails. Decryptor error itself
+// is not exposed so clients can not learn anything about the failure.
+var ErrDecryptionFailed = errors.New("failed to decrypt field value")
+
+var (
+	decryptorsMu sync.RWMutex
+	decryptors   = map[string]func([]byte) ([]byte, error){}
+)
+
+// RegisterDecryptor registers decryptor for fields tagged with `decrypt:"<name>"` i.e. decrypting opaque object IDs.
+// Decryptor receives every raw input value of the field before any other processing and conversion.
+func RegisterDecryptor(name string, fn func(ciphertext []byte) ([]byte, error)) {
+	decryptorsMu.Lock()
+	defer decryptorsMu.Unlock()
+	decryptors[name] = fn
+}
+
+// decryptValues returns values decrypted by decryptor registered under name
+func decryptValues(name string, sourceParam string, values []string) ([]string, error) {
+	decryptorsMu.RLock()
+	fn, ok := decryptors[name]
+	decryptorsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("decryptor %q is not registered", name)
+	}
+
+	decrypted := make([]string, len(values))
+	for i, v := range values {
+		plaintext, err := fn([]byte(v))
+		if err != nil {
+			return nil, &BindingError{
+				Field:         sourceParam,
+				Values:        []string{v},
+				Message:       ErrDecryptionFailed.Error(),
+				InternalError: ErrDecryptionFailed,
+			}
+		}
+		decrypted[i] = string(plaintext)
+	}
+	return decrypted, nil
+}
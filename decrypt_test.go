@@ -0,0 +1,61 @@
+package binding
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func init() {
+	// reverses the ciphertext, failing on ciphertext not ending with `!`
+	RegisterDecryptor("test-reverse", func(ciphertext []byte) ([]byte, error) {
+		if !bytes.HasSuffix(ciphertext, []byte("!")) {
+			return nil, errors.New("secret key mismatch")
+		}
+		plaintext := make([]byte, 0, len(ciphertext)-1)
+		for i := len(ciphertext) - 2; i >= 0; i-- {
+			plaintext = append(plaintext, ciphertext[i])
+		}
+		return plaintext, nil
+	})
+}
+
+func TestBindQueryParams_Decrypt(t *testing.T) {
+	type query struct {
+		OrderID int      `query:"order" decrypt:"test-reverse" trimprefix:"o_"`
+		IDs     []string `query:"id" decrypt:"test-reverse"`
+	}
+	var dest query
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?order=24_o!&id=ba!&id=dc!", "", ""), &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (query{OrderID: 42, IDs: []string{"ab", "cd"}}); !reflect.DeepEqual(dest, want) {
+		t.Errorf("got %+v, want %+v", dest, want)
+	}
+}
+
+func TestBindQueryParams_DecryptionFailed(t *testing.T) {
+	var dest struct {
+		ID string `query:"id" decrypt:"test-reverse"`
+	}
+	err := BindQueryParams(newRequest(http.MethodGet, "/?id=plain", "", ""), &dest)
+	if !errors.Is(err, ErrDecryptionFailed) {
+		t.Fatalf("got error %v, want ErrDecryptionFailed", err)
+	}
+	if strings.Contains(err.Error(), "secret key") {
+		t.Errorf("error %q exposes decryptor error", err)
+	}
+	if dest.ID != "" {
+		t.Errorf("ID = %q, want unbound", dest.ID)
+	}
+
+	var missing struct {
+		ID string `query:"id" decrypt:"test-missing"`
+	}
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?id=x!", "", ""), &missing); err == nil {
+		t.Error("expected error for unregistered decryptor")
+	}
+}
@@ -0,0 +1,91 @@
+package binding
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// DefaultFunc computes default value of a field tagged with `default:"@<name>"`. It receives pointer to the struct
+// containing the field, so the default can be derived from sibling fields, and returns value assignable to the field.
+type DefaultFunc func(parent interface{}) (interface{}, error)
+
+var (
+	defaultFuncsMu sync.RWMutex
+	defaultFuncs   = map[string]DefaultFunc{}
+)
+
+// RegisterDefaultFunc registers function computing defaults for fields tagged with `default:"@<name>"`. Computed
+// defaults are applied after all sources of a bind call have been bound, to fields that received no input value and
+// are still zero (i.e. were not set by body either).
+//
+//	binding.RegisterDefaultFunc("endDateFromStart", func(parent interface{}) (interface{}, error) {
+//		return parent.(*Search).StartDate.AddDate(0, 0, 7), nil
+//	})
+func RegisterDefaultFunc(name string, fn DefaultFunc) {
+	defaultFuncsMu.Lock()
+	defer defaultFuncsMu.Unlock()
+	defaultFuncs[name] = fn
+}
+
+// applyComputedDefaults sets fields with computed default that were not bound during the bind operation
+func applyComputedDefaults(s *bindState, path string, val reflect.Value) error {
+	typ := val.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		typeField := typ.Field(i)
+		structField := val.Field(i)
+		if !structField.CanSet() {
+			continue
+		}
+		fPath := fieldPath(path, typeField)
+
+		name, ok := strings.CutPrefix(typeField.Tag.Get("default"), "@")
+		if !ok {
+			if structField.Kind() == reflect.Struct {
+				if err := applyComputedDefaults(s, fPath, structField); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if s.present[fPath] || !structField.IsZero() {
+			continue
+		}
+
+		defaultFuncsMu.RLock()
+		fn, ok := defaultFuncs[name]
+		defaultFuncsMu.RUnlock()
+		if !ok {
+			return fmt.Errorf("default function %q is not registered", name)
+		}
+		v, err := fn(val.Addr().Interface())
+		if err != nil {
+			return err
+		}
+		if v == nil {
+			continue
+		}
+		dv := reflect.ValueOf(v)
+		if !dv.Type().AssignableTo(structField.Type()) {
+			return fmt.Errorf("default function %q returned %v which is not assignable to field %s of type %v", name, dv.Type(), fPath, structField.Type())
+		}
+		structField.Set(dv)
+	}
+	return nil
+}
+
+// setStaticDefault binds value of field `default:"<value>"` tag, as if it was sent in the request, when the key is
+// missing from the source. Default of slice field is split on commas. Fields already bound from previous source are
+// left untouched.
+func setStaticDefault(s *bindState, tag, path string, typeField reflect.StructField, structField reflect.Value) error {
+	def, ok := typeField.Tag.Lookup("default")
+	if !ok || strings.HasPrefix(def, "@") || s.present[path] {
+		return nil
+	}
+	values := []string{def}
+	if isSliceType(typeField.Type) {
+		values = strings.Split(def, ",")
+	}
+	return setField(s, fieldInput{tag: tag, path: path, field: typeField, values: values}, structField)
+}
@@ -0,0 +1,109 @@
+package binding
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+type defaultsQuery struct {
+	Page    int      `query:"page" default:"1"`
+	Limit   *int     `query:"limit" default:"20"`
+	Verbose bool     `query:"verbose" default:"true"`
+	Sort    string   `query:"sort" default:"name"`
+	Tags    []string `query:"tags" default:"a,b"`
+}
+
+func TestBindQueryParams_Defaults(t *testing.T) {
+	for _, target := range []string{"/", "/?x=1"} {
+		var dest defaultsQuery
+		if err := BindQueryParams(newRequest(http.MethodGet, target, "", ""), &dest); err != nil {
+			t.Fatalf("%s: %v", target, err)
+		}
+		if dest.Page != 1 || dest.Limit == nil || *dest.Limit != 20 || !dest.Verbose || dest.Sort != "name" ||
+			!reflect.DeepEqual(dest.Tags, []string{"a", "b"}) {
+			t.Fatalf("%s: defaults not applied: %+v", target, dest)
+		}
+	}
+}
+
+func TestBindQueryParams_DefaultsNotAppliedToSentValues(t *testing.T) {
+	var dest defaultsQuery
+	req := newRequest(http.MethodGet, "/?page=3&limit=5&verbose=false&sort=&tags=c", "", "")
+	if err := BindQueryParams(req, &dest); err != nil {
+		t.Fatal(err)
+	}
+	if dest.Page != 3 || *dest.Limit != 5 || dest.Verbose || dest.Sort != "" || !reflect.DeepEqual(dest.Tags, []string{"c"}) {
+		t.Fatalf("sent values replaced by defaults: %+v", dest)
+	}
+}
+
+func TestBind_DefaultKeepsValueOfPreviousSource(t *testing.T) {
+	var dest struct {
+		Page int `param:"page" query:"page" default:"1"`
+	}
+	req := newRequest(http.MethodGet, "/", "", "")
+	req.SetPathValue("page", "4")
+	if err := Bind(req, &dest); err != nil {
+		t.Fatal(err)
+	}
+	if dest.Page != 4 {
+		t.Fatalf("expected path value, got %d", dest.Page)
+	}
+}
+
+type computedRange struct {
+	Start int `query:"start"`
+	End   int `query:"end" default:"@test-end-from-start"`
+}
+
+func init() {
+	RegisterDefaultFunc("test-end-from-start", func(parent interface{}) (interface{}, error) {
+		return parent.(*computedRange).Start + 7, nil
+	})
+	RegisterDefaultFunc("test-wrong-type", func(interface{}) (interface{}, error) { return "x", nil })
+}
+
+func TestBindQueryParams_ComputedDefaults(t *testing.T) {
+	var dest struct {
+		Range computedRange
+	}
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?start=3", "", ""), &dest); err != nil {
+		t.Fatal(err)
+	}
+	if dest.Range != (computedRange{Start: 3, End: 10}) {
+		t.Errorf("got %+v, want End computed from Start", dest.Range)
+	}
+
+	var sent computedRange
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?start=3&end=0", "", ""), &sent); err != nil {
+		t.Fatal(err)
+	}
+	if sent != (computedRange{Start: 3}) {
+		t.Errorf("got %+v, want sent End kept", sent)
+	}
+
+	// computed after all sources, so value from the body counts
+	var body computedRange
+	if err := Bind(newRequest(http.MethodPost, "/?start=3", MIMEApplicationJSON, `{"End":1}`), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body != (computedRange{Start: 3, End: 1}) {
+		t.Errorf("got %+v, want End from body kept", body)
+	}
+}
+
+func TestBindQueryParams_ComputedDefaultErrors(t *testing.T) {
+	var missing struct {
+		V int `query:"v" default:"@test-missing"`
+	}
+	if err := BindQueryParams(newRequest(http.MethodGet, "/", "", ""), &missing); err == nil {
+		t.Error("expected error for unregistered default function")
+	}
+	var wrongType struct {
+		V int `query:"v" default:"@test-wrong-type"`
+	}
+	if err := BindQueryParams(newRequest(http.MethodGet, "/", "", ""), &wrongType); err == nil {
+		t.Error("expected error for default of wrong type")
+	}
+}
@@ -0,0 +1,55 @@
+package binding
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// bindElements runs decode on request body of calls binding lists of elements (BindBatch, BindStream, BindCSV and
+// BindEvents) once content type of the body is accepted by isMediaType. Empty body binds nothing.
+func (b *DefaultBinder) bindElements(r *http.Request, isMediaType func(string) bool, decode func(*bindState) error) error {
+	s := b.newState(r)
+	s.groups = true
+	return b.run(s, nil, func() error {
+		if r.ContentLength == 0 {
+			return nil
+		}
+		if err := b.checkContentType(r); err != nil {
+			return err
+		}
+		cType := r.Header.Get(HeaderContentType)
+		if mediaType, _, err := mime.ParseMediaType(cType); err != nil || !isMediaType(mediaType) {
+			return &UnsupportedMediaTypeError{ContentType: cType}
+		}
+		return b.readBody(r, s, func() error { return decode(s) })
+	})
+}
+
+// finishElement runs steps of single element the way run does for whole bind operation, computed defaults, templates,
+// field groups and DefaultBinder.Validator
+func (b *DefaultBinder) finishElement(s *bindState, elem interface{}) error {
+	if err := b.afterBind(elem, s); err != nil {
+		return err
+	}
+	if b.Validator != nil {
+		return b.Validator(elem)
+	}
+	return nil
+}
+
+// isJSONMediaType reports whether media type is `application/json` or structured `+json` one
+func isJSONMediaType(mediaType string) bool {
+	return mediaType == MIMEApplicationJSON || strings.HasSuffix(mediaType, "+json")
+}
+
+// sliceDestination returns slice pointed to by destination of calls binding lists of elements
+func sliceDestination(destination interface{}) (reflect.Value, error) {
+	val := reflect.ValueOf(destination)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Slice {
+		return reflect.Value{}, errors.New("binding element must be a pointer to slice")
+	}
+	return val.Elem(), nil
+}
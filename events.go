@@ -0,0 +1,102 @@
+package binding
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+)
+
+// ErrUnknownEventType is matched by errors of BindEvents for elements whose discriminator has no registered type
+var ErrUnknownEventType = errors.New("unknown event type")
+
+var (
+	eventTypesMu sync.RWMutex
+	eventTypes   = map[string]func() interface{}{}
+)
+
+// RegisterEventType registers factory of concrete event type for elements of BindEvents with discriminator value
+// name. Factory returns a new pointer the element is decoded into, i.e. `func() interface{} { return &OrderCreated{} }`,
+// which must be assignable to the element type of the destination slice.
+func RegisterEventType(name string, fn func() interface{}) {
+	eventTypesMu.Lock()
+	defer eventTypesMu.Unlock()
+	eventTypes[name] = fn
+}
+
+func lookupEventType(name string) (func() interface{}, bool) {
+	eventTypesMu.RLock()
+	defer eventTypesMu.RUnlock()
+	fn, ok := eventTypes[name]
+	return fn, ok
+}
+
+// BindEvents binds JSON array body of heterogeneous events into slice pointed to by events, usually of an interface
+// type (`*[]Event`). Every element is decoded into value created by factory registered with RegisterEventType for its
+// discriminator, string member named by DefaultBinder.EventTypeKey (`type` by default). Elements with unknown or
+// missing discriminator fail with error matching ErrUnknownEventType, unless DefaultBinder.SkipUnknownEvents drops
+// them. Fields readonly for body keep values set by the factory. Elements are finished like those of BindBatch, errors
+// identify the index of the element. Empty body binds nothing.
+func BindEvents(r *http.Request, events interface{}) error {
+	return defaultBinder.BindEvents(r, events)
+}
+
+// BindEvents binds JSON array of events into concrete types by discriminator. See package level BindEvents.
+func (b *DefaultBinder) BindEvents(r *http.Request, events interface{}) error {
+	slice, err := sliceDestination(events)
+	if err != nil {
+		return err
+	}
+	return b.bindElements(r, isJSONMediaType, func(s *bindState) error { return b.decodeEvents(r, s, slice) })
+}
+
+func (b *DefaultBinder) decodeEvents(r *http.Request, s *bindState, slice reflect.Value) error {
+	var raws []json.RawMessage
+	if err := b.decodeJSON(r.Body, &raws); err != nil {
+		return err
+	}
+	key := b.EventTypeKey
+	if key == "" {
+		key = "type"
+	}
+	result := reflect.MakeSlice(slice.Type(), 0, len(raws))
+	for n, raw := range raws {
+		var head map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &head); err != nil {
+			return fmt.Errorf("event %d: %w", n, err)
+		}
+		var name string
+		if discriminator, ok := head[key]; !ok || json.Unmarshal(discriminator, &name) != nil {
+			if b.SkipUnknownEvents {
+				continue
+			}
+			return fmt.Errorf("event %d: %w: missing %q", n, ErrUnknownEventType, key)
+		}
+		newEvent, ok := lookupEventType(name)
+		if !ok {
+			if b.SkipUnknownEvents {
+				continue
+			}
+			return fmt.Errorf("event %d: %w: %q", n, ErrUnknownEventType, name)
+		}
+		event := newEvent()
+		elem := reflect.ValueOf(event)
+		if elem.Kind() != reflect.Ptr || elem.IsNil() || !elem.Type().AssignableTo(slice.Type().Elem()) {
+			return fmt.Errorf("event %d: type %T of %q can not be bound into slice of %v", n, event, name, slice.Type().Elem())
+		}
+		restore := preserveReadonly(event)
+		if err := b.decodeJSON(bytes.NewReader(raw), event); err != nil {
+			return fmt.Errorf("event %d: %w", n, err)
+		}
+		restore()
+		if err := b.finishElement(s, event); err != nil {
+			return fmt.Errorf("event %d: %w", n, err)
+		}
+		result = reflect.Append(result, elem)
+	}
+	slice.Set(result)
+	return nil
+}
@@ -0,0 +1,125 @@
+package binding
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testEvent interface{ eventName() string }
+
+type orderCreated struct {
+	Type     string `json:"type"`
+	ID       string `json:"id"`
+	Received string `json:"received" readonly:"body"`
+}
+
+func (*orderCreated) eventName() string { return "order.created" }
+
+type orderRefunded struct {
+	Type   string `json:"type"`
+	Kind   string `json:"kind"`
+	ID     string `json:"id"`
+	Amount int    `json:"amount"`
+}
+
+func (*orderRefunded) eventName() string { return "order.refunded" }
+
+func init() {
+	RegisterEventType("order.created", func() interface{} { return &orderCreated{Received: "server"} })
+	RegisterEventType("order.refunded", func() interface{} { return &orderRefunded{} })
+	RegisterEventType("order.plain", func() interface{} { return &struct{ ID string }{} })
+}
+
+func TestBindEvents(t *testing.T) {
+	body := `[{"type":"order.created","id":"o1"},{"type":"order.refunded","id":"o1","amount":5}]`
+	var events []testEvent
+	if err := BindEvents(newRequest(http.MethodPost, "/", MIMEApplicationJSON, body), &events); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []testEvent{
+		&orderCreated{Type: "order.created", ID: "o1", Received: "server"},
+		&orderRefunded{Type: "order.refunded", ID: "o1", Amount: 5},
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("got %+v, want %+v", events, want)
+	}
+}
+
+func TestBindEvents_ReadonlyKeepsFactoryValue(t *testing.T) {
+	body := `[{"type":"order.created","id":"o1","received":"client"}]`
+	var events []testEvent
+	if err := BindEvents(newRequest(http.MethodPost, "/", MIMEApplicationJSON, body), &events); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := events[0].(*orderCreated).Received; got != "server" {
+		t.Errorf("Received = %q, want readonly value set by factory", got)
+	}
+}
+
+func TestBindEvents_UnknownType(t *testing.T) {
+	testCases := []struct {
+		name string
+		body string
+		want string
+	}{
+		{name: "unregistered", body: `[{"type":"order.created"},{"type":"order.lost"}]`, want: `event 1: unknown event type: "order.lost"`},
+		{name: "missing discriminator", body: `[{"id":"o1"}]`, want: `event 0: unknown event type: missing "type"`},
+		{name: "non string discriminator", body: `[{"type":1}]`, want: `event 0: unknown event type: missing "type"`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var events []testEvent
+			err := BindEvents(newRequest(http.MethodPost, "/", MIMEApplicationJSON, tc.body), &events)
+			if !errors.Is(err, ErrUnknownEventType) || err.Error() != tc.want {
+				t.Errorf("error = %v, want %q", err, tc.want)
+			}
+		})
+	}
+
+	body := `[{"id":"o0"},{"type":"order.lost"},{"type":"order.refunded","id":"o1"}]`
+	var events []testEvent
+	if err := NewBinder(WithSkipUnknownEvents(true)).BindEvents(newRequest(http.MethodPost, "/", MIMEApplicationJSON, body), &events); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 1 || events[0].eventName() != "order.refunded" {
+		t.Errorf("got %+v, want only the registered event", events)
+	}
+}
+
+func TestBindEvents_TypeKey(t *testing.T) {
+	body := `[{"kind":"order.refunded","type":"ignored","amount":7}]`
+	var events []testEvent
+	if err := NewBinder(WithEventTypeKey("kind")).BindEvents(newRequest(http.MethodPost, "/", MIMEApplicationJSON, body), &events); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e, ok := events[0].(*orderRefunded); !ok || e.Amount != 7 || e.Kind != "order.refunded" {
+		t.Errorf("got %+v", events)
+	}
+}
+
+func TestBindEvents_Rejects(t *testing.T) {
+	var events []testEvent
+	err := BindEvents(newRequest(http.MethodPost, "/", MIMEApplicationJSON, `[{"type":"order.plain"}]`), &events)
+	if err == nil || !strings.Contains(err.Error(), "can not be bound into slice of") {
+		t.Errorf("error = %v, want error of type not implementing element type", err)
+	}
+	err = BindEvents(newRequest(http.MethodPost, "/", MIMEApplicationJSON, `[{"type":"order.refunded","amount":"x"}]`), &events)
+	if err == nil || !strings.HasPrefix(err.Error(), "event 0: ") {
+		t.Errorf("error = %v, want decode error naming the element", err)
+	}
+	err = BindEvents(newRequest(http.MethodPost, "/", MIMEApplicationJSON, `[1]`), &events)
+	if err == nil || !strings.HasPrefix(err.Error(), "event 0: ") {
+		t.Errorf("error = %v, want error of non object element", err)
+	}
+	var unsupported *UnsupportedMediaTypeError
+	err = BindEvents(newRequest(http.MethodPost, "/", MIMETextCSV, `a`), &events)
+	if !errors.As(err, &unsupported) {
+		t.Errorf("error = %v, want UnsupportedMediaTypeError", err)
+	}
+	if err := BindEvents(newRequest(http.MethodPost, "/", MIMEApplicationJSON, ""), &events); err != nil {
+		t.Errorf("empty body: unexpected error %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+package binding
+
+import (
+	"fmt"
+	"mime/multipart"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	fileHeaderType  = reflect.TypeOf((*multipart.FileHeader)(nil))
+	fileHeadersType = reflect.TypeOf([]*multipart.FileHeader(nil))
+)
+
+// isFileType reports whether uploaded files are bound into field of type t
+func isFileType(t reflect.Type) bool {
+	return t == fileHeaderType || t == fileHeadersType
+}
+
+// bindFiles binds uploaded files of multipart form into *multipart.FileHeader field (first file) or
+// []*multipart.FileHeader field (all files). Files are looked up like other input keys, exact name first and then
+// case-insensitively unless binder is case-sensitive.
+func bindFiles(s *bindState, name string, tagOpts tagOptions, field reflect.Value) error {
+	headers := lookupFiles(s, name)
+	if len(headers) == 0 {
+		if tagOpts.contains("required") {
+			return &BindingError{
+				Field:         name,
+				Values:        []string{},
+				Message:       "required file is missing",
+				InternalError: ErrRequired,
+			}
+		}
+		return nil
+	}
+	if field.Type() == fileHeaderType {
+		field.Set(reflect.ValueOf(headers[0]))
+		return nil
+	}
+	field.Set(reflect.ValueOf(headers))
+	return nil
+}
+
+// lookupFiles returns uploaded files of multipart form named name
+func lookupFiles(s *bindState, name string) []*multipart.FileHeader {
+	headers, ok := s.files[name]
+	if !ok && !s.binder.CaseSensitive {
+		for k, v := range s.files {
+			if strings.EqualFold(k, name) {
+				return v
+			}
+		}
+	}
+	return headers
+}
+
+// fileMeta returns metadata selected by `meta` tag of uploaded files named name as input values, one per file. Keys are
+// `filename`, `content_type` (Content-Type header of the part) and `size` (in bytes).
+func fileMeta(s *bindState, name, key string) ([]string, bool, error) {
+	var meta func(*multipart.FileHeader) string
+	switch key {
+	case "filename":
+		meta = func(fh *multipart.FileHeader) string { return fh.Filename }
+	case "content_type":
+		meta = func(fh *multipart.FileHeader) string { return fh.Header.Get(HeaderContentType) }
+	case "size":
+		meta = func(fh *multipart.FileHeader) string { return strconv.FormatInt(fh.Size, 10) }
+	default:
+		return nil, false, fmt.Errorf("unknown file meta %q", key)
+	}
+	headers := lookupFiles(s, name)
+	if len(headers) == 0 {
+		return nil, false, nil
+	}
+	values := make([]string, len(headers))
+	for i, fh := range headers {
+		values[i] = meta(fh)
+	}
+	return values, true, nil
+}
@@ -0,0 +1,113 @@
+package binding
+
+import (
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+type upload struct {
+	Title       string                  `form:"title"`
+	Avatar      *multipart.FileHeader   `form:"avatar"`
+	Attachments []*multipart.FileHeader `form:"attachments"`
+}
+
+func readFile(t *testing.T, fh *multipart.FileHeader) string {
+	t.Helper()
+	f, err := fh.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	content, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+func TestBindBody_MultipartFiles(t *testing.T) {
+	for _, memory := range []int64{0, 1} {
+		files := []string{"avatar", "first avatar", "attachments", "a", "avatar", "second avatar", "attachments", "b"}
+		req := newMultipartRequest(t, "/", []string{"title", "t"}, files)
+		var dest upload
+		// 1 byte of memory stores file parts in temporary files
+		if err := (&DefaultBinder{MultipartMemory: memory}).BindBody(req, &dest); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if dest.Title != "t" {
+			t.Errorf("Title = %q, want t", dest.Title)
+		}
+		if dest.Avatar == nil || readFile(t, dest.Avatar) != "first avatar" || dest.Avatar.Filename != "avatar0.txt" {
+			t.Errorf("Avatar = %+v, want first of the files", dest.Avatar)
+		}
+		if len(dest.Attachments) != 2 || readFile(t, dest.Attachments[0]) != "a" || readFile(t, dest.Attachments[1]) != "b" {
+			t.Errorf("Attachments = %+v, want both files", dest.Attachments)
+		}
+		if err := req.MultipartForm.RemoveAll(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestBindBody_RequiredMultipartFile(t *testing.T) {
+	var dest struct {
+		Avatar *multipart.FileHeader `form:"avatar,required"`
+	}
+	err := BindBody(newMultipartRequest(t, "/", []string{"avatar", "not a file"}, nil), &dest)
+	if !errors.Is(err, ErrRequired) {
+		t.Fatalf("error = %v, want ErrRequired", err)
+	}
+	if err := BindBody(newMultipartRequest(t, "/", nil, []string{"Avatar", "x"}), &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dest.Avatar == nil || readFile(t, dest.Avatar) != "x" {
+		t.Errorf("Avatar = %+v", dest.Avatar)
+	}
+}
+
+func TestBindForm_FilesIgnoredForURLEncodedForm(t *testing.T) {
+	var dest upload
+	req := newRequest(http.MethodPost, "/", MIMEApplicationForm, "title=t&avatar=x")
+	if err := BindForm(req, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dest.Title != "t" || dest.Avatar != nil || dest.Attachments != nil {
+		t.Errorf("got %+v", dest)
+	}
+}
+
+func TestBindMultipartForm_FileMeta(t *testing.T) {
+	type metaUpload struct {
+		Avatar     *multipart.FileHeader `form:"avatar"`
+		AvatarType string                `form:"avatar" meta:"content_type"`
+		AvatarName string                `form:"avatar" meta:"filename"`
+		AvatarSize int64                 `form:"avatar" meta:"size"`
+		DocNames   []string              `form:"doc" meta:"filename"`
+		DocSizes   []int                 `form:"doc" meta:"size"`
+		Missing    string                `form:"missing" meta:"filename"`
+	}
+	req := newMultipartRequest(t, "/", []string{"avatar", "text value", "missing", "text"},
+		[]string{"avatar", "12345", "doc", "a", "doc", "bcd"})
+	var dest metaUpload
+	if err := BindMultipartForm(req, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dest.Avatar == nil || dest.AvatarType != "application/octet-stream" || dest.AvatarName != "avatar0.txt" ||
+		dest.AvatarSize != 5 || dest.Missing != "" {
+		t.Errorf("got %+v", dest)
+	}
+	if !reflect.DeepEqual(dest.DocNames, []string{"doc1.txt", "doc2.txt"}) || !reflect.DeepEqual(dest.DocSizes, []int{1, 3}) {
+		t.Errorf("DocNames = %v, DocSizes = %v", dest.DocNames, dest.DocSizes)
+	}
+
+	var unknown struct {
+		Avatar string `form:"avatar" meta:"checksum"`
+	}
+	if err := BindMultipartForm(newMultipartRequest(t, "/", nil, []string{"avatar", "x"}), &unknown); err == nil {
+		t.Error("unknown meta key: expected error")
+	}
+}
@@ -0,0 +1,560 @@
+package binding
+
+import (
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// formatFunc decodes input values of a field tagged with `format:"<name>"` directly into the field.
+type formatFunc func(s *bindState, in fieldInput, field reflect.Value) error
+
+// fieldInput describes the input of a single struct field
+type fieldInput struct {
+	// tag is the source tag values were read for i.e. `query`
+	tag string
+	// path is the field path in relation to the root struct
+	path string
+	// name is the input key values were read from, empty when values do not come from the request i.e. defaults
+	name string
+	// field is the struct field being bound
+	field reflect.StructField
+	// values are input values of the field
+	values []string
+}
+
+// ErrInvalidFormat is returned when input value does not match the format required by field `format` tag
+var ErrInvalidFormat = errors.New("invalid format")
+
+// formatValidators check input values of a field tagged with `format:"<name>"`. Unlike formats, validated value is
+// bound with the regular conversion rules afterwards.
+var formatValidators = map[string]func(value string) error{
+	"uuid": validateUUID,
+}
+
+// formats holds decoders selected by the `format` struct tag. Fields whose format is not listed here are bound with
+// the regular conversion rules.
+var formats map[string]formatFunc
+
+func init() {
+	// initialized here as decoders that bind nested values refer back to bindData
+	formats = map[string]formatFunc{
+		"json":             formatJSON,
+		"semicolon-kv":     formatSemicolonKV,
+		"ordered-kv":       formatOrderedKV,
+		"list":             formatList,
+		"base64":           formatBase64,
+		"cursor":           formatCursor,
+		"csv":              formatCSV,
+		"qvalue":           formatQValue,
+		"etag-list":        formatETagList,
+		"duration-or-time": formatDurationOrTime,
+		"forwarded":        formatForwarded,
+		"client-ip":        formatClientIP,
+		"link":             formatLink,
+		"language":         formatLanguage,
+		"tenant":           formatTenant,
+	}
+}
+
+// formatJSON unmarshals a JSON encoded parameter i.e. `?ids=[1,2,3]` into the field. Values that are still
+// percent-encoded (clients encoding JSON twice) are unescaped before decoding.
+func formatJSON(_ *bindState, in fieldInput, field reflect.Value) error {
+	raw := in.values[0]
+	if !json.Valid([]byte(raw)) {
+		if unescaped, err := url.QueryUnescape(raw); err == nil {
+			raw = unescaped
+		}
+	}
+	return unmarshalJSONValue(in.values[0], []byte(raw), field)
+}
+
+func unmarshalJSONValue(value string, raw []byte, field reflect.Value) error {
+	if err := json.Unmarshal(raw, field.Addr().Interface()); err != nil {
+		return fmt.Errorf("malformed JSON value %q: %w", value, err)
+	}
+	return nil
+}
+
+// formatBase64 decodes base64 value (standard or URL alphabet, padding is optional) into []byte or string field
+func formatBase64(_ *bindState, in fieldInput, field reflect.Value) error {
+	b, err := decodeBase64(in.values[0])
+	if err != nil {
+		return err
+	}
+	field = allocPointer(field)
+	switch {
+	case field.Kind() == reflect.String:
+		field.SetString(string(b))
+	case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.Uint8:
+		field.SetBytes(b)
+	default:
+		return fmt.Errorf("base64 value can not be bound into field of type %v", field.Type())
+	}
+	return nil
+}
+
+// formatCursor decodes pagination cursor, base64 encoded JSON, into the field
+func formatCursor(_ *bindState, in fieldInput, field reflect.Value) error {
+	b, err := decodeBase64(in.values[0])
+	if err != nil {
+		return err
+	}
+	return unmarshalJSONValue(in.values[0], b, field)
+}
+
+// decodeBase64 decodes value encoded with standard or URL alphabet with or without padding
+func decodeBase64(value string) ([]byte, error) {
+	value = strings.TrimRight(value, "=")
+	encoding := base64.RawStdEncoding
+	if strings.ContainsAny(value, "-_") {
+		encoding = base64.RawURLEncoding
+	}
+	b, err := encoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed base64 value %q: %v", ErrInvalidFormat, value, err)
+	}
+	return b, nil
+}
+
+// formatSemicolonKV splits a value like `tenant=acme; region=us` on `;` and then `=` and binds resulting key/value
+// pairs into a map or struct field (using the same source tag for the struct fields). Whitespace around segments,
+// keys and values is trimmed, segments without `=` bind as keys with empty value.
+func formatSemicolonKV(s *bindState, in fieldInput, field reflect.Value) error {
+	data := map[string][]string{}
+	for _, segment := range strings.Split(in.values[0], ";") {
+		segment = strings.TrimSpace(segment)
+		if segment == "" {
+			continue
+		}
+		k, v, _ := strings.Cut(segment, "=")
+		k = strings.TrimSpace(k)
+		data[k] = append(data[k], strings.TrimSpace(v))
+	}
+	return bindData(s, in.path, allocPointer(field).Addr().Interface(), data, in.tag)
+}
+
+// formatOrderedKV splits values like `a=1;b=2` on `;` and then `=` like formatSemicolonKV and binds pairs in order of
+// appearance into slice of structs with string `Key` and `Value` fields. Repeated headers are combined in order and
+// duplicate keys are kept as separate elements.
+func formatOrderedKV(_ *bindState, in fieldInput, field reflect.Value) error {
+	field = allocPointer(field)
+	typ := field.Type()
+	if typ.Kind() != reflect.Slice || typ.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("ordered-kv value can not be bound into field of type %v", typ)
+	}
+	key, keyOk := typ.Elem().FieldByName("Key")
+	value, valueOk := typ.Elem().FieldByName("Value")
+	if !keyOk || !valueOk || key.Type.Kind() != reflect.String || value.Type.Kind() != reflect.String {
+		return fmt.Errorf("ordered-kv value requires slice of structs with string Key and Value fields, got %v", typ)
+	}
+	slice := reflect.MakeSlice(typ, 0, len(in.values))
+	for _, v := range in.values {
+		for _, segment := range strings.Split(v, ";") {
+			segment = strings.TrimSpace(segment)
+			if segment == "" {
+				continue
+			}
+			k, val, _ := strings.Cut(segment, "=")
+			pair := reflect.New(typ.Elem()).Elem()
+			pair.FieldByIndex(key.Index).SetString(strings.TrimSpace(k))
+			pair.FieldByIndex(value.Index).SetString(strings.TrimSpace(val))
+			slice = reflect.Append(slice, pair)
+		}
+	}
+	field.Set(slice)
+	return nil
+}
+
+// formatCSV binds CSV record i.e. `?row=1,foo,true` into struct field by position, segment N is bound into the field
+// tagged `pos:"N"` with the regular conversion rules. Number of segments must match number of positional fields.
+func formatCSV(s *bindState, in fieldInput, field reflect.Value) error {
+	field = allocPointer(field)
+	if field.Kind() != reflect.Struct {
+		return fmt.Errorf("csv value can not be bound into field of type %v", field.Type())
+	}
+	reader := csv.NewReader(strings.NewReader(in.values[0]))
+	reader.TrimLeadingSpace = true
+	record, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("malformed CSV value %q: %w", in.values[0], err)
+	}
+
+	typ := field.Type()
+	positions := map[int]int{}
+	for i := 0; i < typ.NumField(); i++ {
+		pos, ok := typ.Field(i).Tag.Lookup("pos")
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(pos)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid pos %q of field %s", pos, typ.Field(i).Name)
+		}
+		positions[n] = i
+	}
+	if len(record) != len(positions) {
+		return fmt.Errorf("CSV value %q has %d segments, expected %d", in.values[0], len(record), len(positions))
+	}
+
+	for n, segment := range record {
+		i, ok := positions[n]
+		if !ok {
+			return fmt.Errorf("CSV value %q has no field for segment %d", in.values[0], n)
+		}
+		typeField := typ.Field(i)
+		segmentIn := fieldInput{tag: in.tag, path: fieldPath(in.path, typeField), name: in.name, field: typeField, values: []string{segment}}
+		target := allocPointer(field.Field(i))
+		if err := setWithProperType(s, &segmentIn, target.Kind(), segment, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// allocPointer returns field itself or, for pointer fields, the value field points to. Nil pointer is allocated.
+func allocPointer(field reflect.Value) reflect.Value {
+	if field.Kind() != reflect.Ptr {
+		return field
+	}
+	if field.IsNil() {
+		field.Set(reflect.New(field.Type().Elem()))
+	}
+	return field.Elem()
+}
+
+// formatQValue parses quality-value list i.e. `Accept-Language: fr;q=0.8, en-US, en;q=0.9` and binds its elements
+// without parameters sorted by quality, highest first (`en-US`, `en`, `fr`). Elements of the same quality keep order
+// of appearance. Elements with zero quality (not acceptable) or malformed q parameter are dropped.
+func formatQValue(s *bindState, in fieldInput, field reflect.Value) error {
+	type item struct {
+		value string
+		q     float64
+	}
+	var items []item
+	for _, v := range in.values {
+		for _, elem := range strings.Split(v, ",") {
+			value, params, _ := strings.Cut(elem, ";")
+			if value = strings.TrimSpace(value); value == "" {
+				continue
+			}
+			q, ok := parseQuality(params)
+			if !ok || q == 0 {
+				continue
+			}
+			items = append(items, item{value: value, q: q})
+		}
+	}
+	if len(items) == 0 {
+		return nil
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].q > items[j].q })
+
+	in.values = make([]string, len(items))
+	for i, it := range items {
+		in.values[i] = it.value
+	}
+	return setField(s, in, field)
+}
+
+// parseQuality returns q parameter of `;` separated element parameters, 1 when there is none
+func parseQuality(params string) (float64, bool) {
+	for _, param := range strings.Split(params, ";") {
+		k, v, _ := strings.Cut(param, "=")
+		if !strings.EqualFold(strings.TrimSpace(k), "q") {
+			continue
+		}
+		q, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil || q < 0 || q > 1 {
+			return 0, false
+		}
+		return q, true
+	}
+	return 1, true
+}
+
+// formatETagList parses entity-tag list of `If-Match`/`If-None-Match` header i.e. `W/"a", "b,c", *` and binds its
+// entity-tags as sent (`W/"a"`, `"b,c"`, `*`) so weak validators stay distinguishable. Repeated headers are combined
+// in order of appearance.
+func formatETagList(s *bindState, in fieldInput, field reflect.Value) error {
+	var tags []string
+	for _, v := range in.values {
+		for v = strings.TrimSpace(v); v != ""; v = strings.TrimSpace(v) {
+			if v[0] == ',' {
+				v = v[1:]
+				continue
+			}
+			if v[0] == '*' {
+				tags = append(tags, "*")
+				v = v[1:]
+				continue
+			}
+			start := 0
+			if strings.HasPrefix(v, "W/") {
+				start = 2
+			}
+			end := -1
+			if len(v) > start && v[start] == '"' {
+				end = strings.IndexByte(v[start+1:], '"')
+			}
+			if end < 0 {
+				return fmt.Errorf("%w: malformed entity-tag list %q", ErrInvalidFormat, in.values)
+			}
+			end += start + 2
+			tags = append(tags, v[:end])
+			v = v[end:]
+		}
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+	in.values = tags
+	return setField(s, in, field)
+}
+
+// formatDurationOrTime binds value that is either a duration i.e. `1h`, bound as now plus the duration, or RFC3339
+// timestamp into time.Time field. Now is DefaultBinder.Now (time.Now by default) so result has its location.
+func formatDurationOrTime(s *bindState, in fieldInput, field reflect.Value) error {
+	field = allocPointer(field)
+	if field.Type() != timeType {
+		return fmt.Errorf("duration-or-time value can not be bound into field of type %v", field.Type())
+	}
+	value := in.values[0]
+	if d, err := time.ParseDuration(value); err == nil {
+		field.Set(reflect.ValueOf(s.binder.now().Add(d)))
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return fmt.Errorf("%w: %q is neither a duration nor RFC3339 time", ErrInvalidFormat, value)
+	}
+	field.Set(reflect.ValueOf(t))
+	return nil
+}
+
+// formatForwarded parses RFC 7239 `Forwarded` header i.e. `for=192.0.2.60;proto=https, for="[2001:db8::1]:4711"` and
+// binds pairs of its elements into a struct or map field using the same source tag (`header:"for"`). Slice field
+// receives every element in order of appearance (repeated headers are combined), other fields the first one, the
+// element added by the proxy closest to the client.
+func formatForwarded(s *bindState, in fieldInput, field reflect.Value) error {
+	elements, err := parseForwarded(in.values)
+	if err != nil || len(elements) == 0 {
+		return err
+	}
+	field = allocPointer(field)
+	if field.Kind() != reflect.Slice {
+		return bindData(s, in.path, field.Addr().Interface(), elements[0], in.tag)
+	}
+	slice := reflect.MakeSlice(field.Type(), len(elements), len(elements))
+	for i, element := range elements {
+		if err := bindData(s, in.path, allocPointer(slice.Index(i)).Addr().Interface(), element, in.tag); err != nil {
+			return err
+		}
+	}
+	field.Set(slice)
+	return nil
+}
+
+// parseForwarded splits `Forwarded` header values into elements of `;` separated `key=value` pairs. Keys are lower
+// cased as they are case-insensitive, quoted values are unquoted.
+func parseForwarded(values []string) ([]map[string][]string, error) {
+	var elements []map[string][]string
+	for _, v := range values {
+		element := map[string][]string{}
+		for i := 0; i <= len(v); {
+			i = skipSpace(v, i)
+			if i == len(v) || v[i] == ',' {
+				if len(element) > 0 {
+					elements = append(elements, element)
+					element = map[string][]string{}
+				}
+				i++
+				continue
+			}
+			if v[i] == ';' {
+				i++
+				continue
+			}
+			eq := strings.IndexByte(v[i:], '=')
+			if eq <= 0 {
+				return nil, fmt.Errorf("%w: malformed Forwarded header %q", ErrInvalidFormat, v)
+			}
+			key := strings.ToLower(strings.TrimSpace(v[i : i+eq]))
+			i = skipSpace(v, i+eq+1)
+			var value string
+			if i < len(v) && v[i] == '"' {
+				var ok bool
+				if value, i, ok = readQuoted(v, i); !ok {
+					return nil, fmt.Errorf("%w: malformed Forwarded header %q", ErrInvalidFormat, v)
+				}
+			} else {
+				end := strings.IndexAny(v[i:], ";, \t")
+				if end < 0 {
+					end = len(v) - i
+				}
+				value, i = v[i:i+end], i+end
+			}
+			element[key] = append(element[key], value)
+		}
+	}
+	return elements, nil
+}
+
+// formatLink parses RFC 8288 (formerly RFC 5988) `Link` headers i.e. `<https://api/items?page=2>; rel="next"` into
+// map[string]string field of link relation types to target URIs. Repeated headers and multiple links per header are
+// combined, link with multiple relation types (`rel="next last"`) is mapped under each of them and the first link of
+// a relation type wins. Links without `rel` are ignored.
+func formatLink(_ *bindState, in fieldInput, field reflect.Value) error {
+	links, err := parseLinks(in.values)
+	if err != nil {
+		return err
+	}
+	field = allocPointer(field)
+	if field.Kind() != reflect.Map || field.Type().Key().Kind() != reflect.String || field.Type().Elem().Kind() != reflect.String {
+		return fmt.Errorf("format link requires map[string]string field, got %v", field.Type())
+	}
+	m := reflect.MakeMapWithSize(field.Type(), len(links))
+	for rel, target := range links {
+		m.SetMapIndex(reflect.ValueOf(rel).Convert(field.Type().Key()), reflect.ValueOf(target).Convert(field.Type().Elem()))
+	}
+	field.Set(m)
+	return nil
+}
+
+// parseLinks returns target URIs of links in `Link` header values keyed by their (lower cased) relation types
+func parseLinks(values []string) (map[string]string, error) {
+	links := map[string]string{}
+	for _, v := range values {
+		for i := 0; ; {
+			i = skipSpace(v, i)
+			if i == len(v) {
+				break
+			}
+			if v[i] == ',' {
+				i++
+				continue
+			}
+			end := strings.IndexByte(v[i:], '>')
+			if v[i] != '<' || end < 0 {
+				return nil, fmt.Errorf("%w: malformed Link header %q", ErrInvalidFormat, v)
+			}
+			target := v[i+1 : i+end]
+			i += end + 1
+			// link params up to the next link
+			for {
+				i = skipSpace(v, i)
+				if i == len(v) || v[i] == ',' {
+					break
+				}
+				if v[i] != ';' {
+					return nil, fmt.Errorf("%w: malformed Link header %q", ErrInvalidFormat, v)
+				}
+				i = skipSpace(v, i+1)
+				end := strings.IndexAny(v[i:], "=;,")
+				if end < 0 {
+					end = len(v) - i
+				}
+				key := strings.ToLower(strings.TrimSpace(v[i : i+end]))
+				i += end
+				if i == len(v) || v[i] != '=' {
+					continue
+				}
+				i = skipSpace(v, i+1)
+				var value string
+				if i < len(v) && v[i] == '"' {
+					var ok bool
+					if value, i, ok = readQuoted(v, i); !ok {
+						return nil, fmt.Errorf("%w: malformed Link header %q", ErrInvalidFormat, v)
+					}
+				} else {
+					end := strings.IndexAny(v[i:], ";,")
+					if end < 0 {
+						end = len(v) - i
+					}
+					value, i = strings.TrimSpace(v[i:i+end]), i+end
+				}
+				if key != "rel" {
+					continue
+				}
+				for _, rel := range strings.Fields(strings.ToLower(value)) {
+					if _, ok := links[rel]; !ok {
+						links[rel] = target
+					}
+				}
+			}
+		}
+	}
+	return links, nil
+}
+
+func skipSpace(v string, i int) int {
+	for i < len(v) && (v[i] == ' ' || v[i] == '\t') {
+		i++
+	}
+	return i
+}
+
+// readQuoted reads quoted-string starting at v[i] and returns its unescaped content and index after closing quote
+func readQuoted(v string, i int) (string, int, bool) {
+	var b strings.Builder
+	for i++; i < len(v); i++ {
+		switch v[i] {
+		case '"':
+			return b.String(), i + 1, true
+		case '\\':
+			if i++; i == len(v) {
+				return "", i, false
+			}
+		}
+		b.WriteByte(v[i])
+	}
+	return "", i, false
+}
+
+// validateUUID checks that value is a UUID in canonical textual form `xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx`
+func validateUUID(value string) error {
+	if len(value) != 36 {
+		return fmt.Errorf("%w: %q is not a valid UUID", ErrInvalidFormat, value)
+	}
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return fmt.Errorf("%w: %q is not a valid UUID", ErrInvalidFormat, value)
+			}
+		default:
+			if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+				return fmt.Errorf("%w: %q is not a valid UUID", ErrInvalidFormat, value)
+			}
+		}
+	}
+	return nil
+}
+
+// formatList splits comma separated list values i.e. `Sec-WebSocket-Protocol: chat, superchat` and binds resulting
+// elements with the regular conversion rules. Multiple values (repeated headers) are combined in order of appearance,
+// whitespace around elements is trimmed and empty elements are dropped.
+func formatList(s *bindState, in fieldInput, field reflect.Value) error {
+	var elems []string
+	for _, v := range in.values {
+		for _, elem := range strings.Split(v, ",") {
+			if elem = strings.TrimSpace(elem); elem != "" {
+				elems = append(elems, elem)
+			}
+		}
+	}
+	if len(elems) == 0 {
+		return nil
+	}
+	in.values = elems
+	return setField(s, in, field)
+}
@@ -0,0 +1,417 @@
+package binding
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func TestBindQueryParams_FormatJSON(t *testing.T) {
+	type filter struct {
+		IDs   []int          `query:"ids" format:"json"`
+		Range map[string]int `query:"range" format:"json"`
+		Sort  struct {
+			By   string `json:"by"`
+			Desc bool   `json:"desc"`
+		} `query:"sort" format:"json"`
+	}
+	// range is percent-encoded twice
+	req := newRequest(http.MethodGet, `/?ids=[1,2,3]&range=%257B%2522min%2522%253A1%257D&sort={"by":"name","desc":true}`, "", "")
+	var dest filter
+	if err := BindQueryParams(req, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(dest.IDs, []int{1, 2, 3}) {
+		t.Errorf("IDs = %v, want [1 2 3]", dest.IDs)
+	}
+	if !reflect.DeepEqual(dest.Range, map[string]int{"min": 1}) {
+		t.Errorf("Range = %v, want map[min:1]", dest.Range)
+	}
+	if dest.Sort.By != "name" || !dest.Sort.Desc {
+		t.Errorf("Sort = %+v, want {By:name Desc:true}", dest.Sort)
+	}
+
+	for _, target := range []string{"/?ids=[1,2", `/?ids=["a"]`, "/?ids=1,2"} {
+		var dest filter
+		if err := BindQueryParams(newRequest(http.MethodGet, target, "", ""), &dest); err == nil {
+			t.Errorf("%s: expected error for malformed JSON", target)
+		}
+	}
+}
+
+func TestBindHeaders_FormatSemicolonKV(t *testing.T) {
+	type context struct {
+		Tenant string `header:"tenant"`
+		Region string `header:"region"`
+	}
+	type headers struct {
+		Map    map[string]string `header:"X-Context" format:"semicolon-kv"`
+		Struct *context          `header:"X-Context" format:"semicolon-kv"`
+	}
+	req := newRequest(http.MethodGet, "/", "", "")
+	req.Header.Set("X-Context", " tenant = acme ;region=us;; flag")
+	var dest headers
+	if err := BindHeaders(req, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := map[string]string{"tenant": "acme", "region": "us", "flag": ""}; !reflect.DeepEqual(dest.Map, want) {
+		t.Errorf("Map = %v, want %v", dest.Map, want)
+	}
+	if dest.Struct == nil || *dest.Struct != (context{Tenant: "acme", Region: "us"}) {
+		t.Errorf("Struct = %+v, want {Tenant:acme Region:us}", dest.Struct)
+	}
+}
+
+func TestBind_FormatUUID(t *testing.T) {
+	type order struct {
+		ID    string   `param:"id" format:"uuid"`
+		Items []string `query:"item" format:"uuid"`
+	}
+	bindPath := func(id, query string) (order, error) {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id)
+		req := newRequest(http.MethodGet, "/orders/"+id+query, "", "")
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		var dest order
+		err := Bind(req, &dest)
+		return dest, err
+	}
+
+	const id = "7d444840-9dc0-11d1-b245-5FFDCE74FAD2"
+	dest, err := bindPath(id, "?item=00000000-0000-0000-0000-000000000000")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dest.ID != id || !reflect.DeepEqual(dest.Items, []string{"00000000-0000-0000-0000-000000000000"}) {
+		t.Errorf("got %+v", dest)
+	}
+
+	for _, tc := range []struct{ id, query string }{
+		{"42", ""},
+		{"7d444840-9dc0-11d1-b245-5ffdce74fad", ""},
+		{"7d444840x9dc0-11d1-b245-5ffdce74fad2", ""},
+		{"7d444840-9dc0-11d1-b245-5ffdce74fagg", ""},
+		{id, "?item=" + id + "&item=nope"},
+	} {
+		if _, err := bindPath(tc.id, tc.query); !errors.Is(err, ErrInvalidFormat) {
+			t.Errorf("%s%s: got error %v, want ErrInvalidFormat", tc.id, tc.query, err)
+		}
+	}
+}
+
+func TestBindHeaders_FormatList(t *testing.T) {
+	type handshake struct {
+		Key        string   `header:"Sec-WebSocket-Key"`
+		Protocols  []string `header:"Sec-WebSocket-Protocol" format:"list"`
+		Extensions []string `header:"Sec-WebSocket-Extensions" format:"list"`
+		Versions   []int    `header:"X-Versions" format:"list"`
+	}
+	req := newRequest(http.MethodGet, "/", "", "")
+	req.Header.Set(HeaderSecWebSocketKey, "dGhlIHNhbXBsZSBub25jZQ==")
+	req.Header.Add(HeaderSecWebSocketProtocol, "chat, superchat")
+	req.Header.Add(HeaderSecWebSocketProtocol, " ,json")
+	req.Header.Set(HeaderSecWebSocketExtensions, " , ")
+	req.Header.Set("X-Versions", "13, 8")
+	var dest handshake
+	if err := BindHeaders(req, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := handshake{Key: "dGhlIHNhbXBsZSBub25jZQ==", Protocols: []string{"chat", "superchat", "json"}, Versions: []int{13, 8}}
+	if !reflect.DeepEqual(dest, want) {
+		t.Errorf("got %+v, want %+v", dest, want)
+	}
+
+	req.Header.Set("X-Versions", "13, latest")
+	if err := BindHeaders(req, &dest); err == nil {
+		t.Error("expected error for invalid list element")
+	}
+}
+
+func TestBindQueryParams_FormatBase64(t *testing.T) {
+	type query struct {
+		Std  []byte  `query:"std" format:"base64"`
+		URL  string  `query:"url" format:"base64"`
+		Ptr  *string `query:"ptr" format:"base64"`
+		Next struct {
+			After int    `json:"after"`
+			ID    string `json:"id"`
+		} `query:"next" format:"cursor"`
+	}
+	req := newRequest(http.MethodGet, "/?std=%2B/%2B%2B&url=-_8&ptr=aGk=&next=eyJhZnRlciI6NDIsImlkIjoieCJ9", "", "")
+	var dest query
+	if err := BindQueryParams(req, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(dest.Std) != "\xfb\xff\xbe" || dest.URL != "\xfb\xff" || dest.Ptr == nil || *dest.Ptr != "hi" {
+		t.Errorf("got %+v", dest)
+	}
+	if dest.Next.After != 42 || dest.Next.ID != "x" {
+		t.Errorf("Next = %+v, want {After:42 ID:x}", dest.Next)
+	}
+
+	for _, target := range []string{"/?std=%25%25", "/?next=%25%25"} {
+		var dest query
+		if err := BindQueryParams(newRequest(http.MethodGet, target, "", ""), &dest); !errors.Is(err, ErrInvalidFormat) {
+			t.Errorf("%s: got error %v, want ErrInvalidFormat", target, err)
+		}
+	}
+	// valid base64 of invalid JSON
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?next=bm9wZQ", "", ""), &dest); err == nil {
+		t.Error("expected error for cursor of malformed JSON")
+	}
+	var wrongType struct {
+		N int `query:"n" format:"base64"`
+	}
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?n=aGk", "", ""), &wrongType); err == nil {
+		t.Error("expected error for base64 into int field")
+	}
+}
+
+type csvTuple struct {
+	ID     int     `pos:"0"`
+	Name   string  `pos:"1"`
+	Active *bool   `pos:"2"`
+	Score  float64 `pos:"3"`
+}
+
+func TestBindQueryParams_FormatCSV(t *testing.T) {
+	type query struct {
+		Row csvTuple  `query:"row" format:"csv"`
+		Ptr *csvTuple `query:"ptr" format:"csv"`
+	}
+	var dest query
+	req := newRequest(http.MethodGet, `/?row=1,"foo,%20bar",true,1.5&ptr=2,%20baz,false,0`, "", "")
+	if err := BindQueryParams(req, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dest.Row.ID != 1 || dest.Row.Name != "foo, bar" || dest.Row.Active == nil || !*dest.Row.Active || dest.Row.Score != 1.5 {
+		t.Errorf("Row = %+v", dest.Row)
+	}
+	if dest.Ptr == nil || dest.Ptr.ID != 2 || dest.Ptr.Name != "baz" || dest.Ptr.Active == nil || *dest.Ptr.Active {
+		t.Errorf("Ptr = %+v", dest.Ptr)
+	}
+
+	for _, target := range []string{`/?row=1,foo,true`, `/?row=1,foo,true,1,extra`, `/?row=x,foo,true,1`, `/?row=1,"foo,true,1`} {
+		var dest query
+		if err := BindQueryParams(newRequest(http.MethodGet, target, "", ""), &dest); err == nil {
+			t.Errorf("%s: expected error", target)
+		}
+	}
+	var notStruct struct {
+		Row []string `query:"row" format:"csv"`
+	}
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?row=a,b", "", ""), &notStruct); err == nil {
+		t.Error("expected error for csv into non-struct field")
+	}
+}
+
+func TestBindHeaders_FormatQValue(t *testing.T) {
+	type headers struct {
+		Languages []string `header:"Accept-Language" format:"qvalue"`
+		Encodings []string `header:"Accept-Encoding" format:"qvalue"`
+		Preferred string   `header:"Accept" format:"qvalue"`
+	}
+	req := newRequest(http.MethodGet, "/", "", "")
+	req.Header.Add("Accept-Language", "fr;q=0.8, en-US, en;q=0.9")
+	req.Header.Add("Accept-Language", "de;Q=0.8, es;q=0, it;q=x, pt;q=1.5")
+	req.Header.Set("Accept-Encoding", "identity;q=0")
+	req.Header.Set("Accept", "text/html;level=1;q=0.5, application/json")
+	var dest headers
+	if err := BindHeaders(req, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := headers{Languages: []string{"en-US", "en", "fr", "de"}, Preferred: "application/json"}
+	if !reflect.DeepEqual(dest, want) {
+		t.Errorf("got %+v, want %+v", dest, want)
+	}
+}
+
+func TestBindHeaders_FormatETagList(t *testing.T) {
+	type conditions struct {
+		IfMatch     []string `header:"If-Match" format:"etag-list"`
+		IfNoneMatch []string `header:"If-None-Match" format:"etag-list"`
+	}
+	req := newRequest(http.MethodGet, "/", "", "")
+	req.Header.Add("If-None-Match", `W/"a", "b,c"`)
+	req.Header.Add("If-None-Match", `*,,"d"`)
+	var dest conditions
+	if err := BindHeaders(req, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{`W/"a"`, `"b,c"`, "*", `"d"`}; !reflect.DeepEqual(dest.IfNoneMatch, want) {
+		t.Errorf("IfNoneMatch = %q, want %q", dest.IfNoneMatch, want)
+	}
+	if dest.IfMatch != nil {
+		t.Errorf("IfMatch = %q, want nil without the header", dest.IfMatch)
+	}
+
+	for _, value := range []string{`"a`, `W/a`, `abc`, `"a" x`} {
+		req := newRequest(http.MethodGet, "/", "", "")
+		req.Header.Set("If-Match", value)
+		var dest conditions
+		if err := BindHeaders(req, &dest); !errors.Is(err, ErrInvalidFormat) {
+			t.Errorf("%s: got error %v, want ErrInvalidFormat", value, err)
+		}
+	}
+}
+
+func TestBindQueryParams_FormatDurationOrTime(t *testing.T) {
+	type query struct {
+		Expires time.Time  `query:"expires" format:"duration-or-time"`
+		Since   *time.Time `query:"since" format:"duration-or-time"`
+	}
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	b := &DefaultBinder{Now: func() time.Time { return now }}
+	var dest query
+	if err := b.BindQueryParams(newRequest(http.MethodGet, "/?expires=1h30m&since=-30m", "", ""), &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !dest.Expires.Equal(now.Add(90*time.Minute)) || dest.Since == nil || !dest.Since.Equal(now.Add(-30*time.Minute)) {
+		t.Errorf("got %+v, want times relative to now", dest)
+	}
+
+	dest = query{}
+	if err := b.BindQueryParams(newRequest(http.MethodGet, "/?expires=2024-06-01T10:00:00%2B02:00", "", ""), &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, offset := dest.Expires.Zone(); !dest.Expires.Equal(time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)) || offset != 2*60*60 {
+		t.Errorf("Expires = %v, want timestamp keeping its offset", dest.Expires)
+	}
+
+	if err := b.BindQueryParams(newRequest(http.MethodGet, "/?expires=tomorrow", "", ""), &dest); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("got error %v, want ErrInvalidFormat", err)
+	}
+	var notTime struct {
+		D time.Duration `query:"d" format:"duration-or-time"`
+	}
+	if err := b.BindQueryParams(newRequest(http.MethodGet, "/?d=1h", "", ""), &notTime); err == nil {
+		t.Error("expected error for field that is not time.Time")
+	}
+}
+
+func TestBindHeaders_FormatForwarded(t *testing.T) {
+	type hop struct {
+		For   string `header:"for"`
+		Proto string `header:"proto"`
+		Host  string `header:"host"`
+	}
+	type headers struct {
+		First *hop              `header:"Forwarded" format:"forwarded"`
+		All   []hop             `header:"Forwarded" format:"forwarded"`
+		Map   map[string]string `header:"Forwarded" format:"forwarded"`
+	}
+	req := newRequest(http.MethodGet, "/", "", "")
+	req.Header.Add("Forwarded", `for=192.0.2.60;Proto=https;host=example.com, for="[2001:db8::1]:4711"`)
+	req.Header.Add("Forwarded", `for="10.0.0.1";host="a\"b"`)
+	var dest headers
+	if err := BindHeaders(req, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	first := hop{For: "192.0.2.60", Proto: "https", Host: "example.com"}
+	if dest.First == nil || *dest.First != first {
+		t.Errorf("First = %+v, want %+v", dest.First, first)
+	}
+	all := []hop{first, {For: "[2001:db8::1]:4711"}, {For: "10.0.0.1", Host: `a"b`}}
+	if !reflect.DeepEqual(dest.All, all) {
+		t.Errorf("All = %+v, want %+v", dest.All, all)
+	}
+	if want := map[string]string{"for": "192.0.2.60", "proto": "https", "host": "example.com"}; !reflect.DeepEqual(dest.Map, want) {
+		t.Errorf("Map = %v, want %v", dest.Map, want)
+	}
+
+	for _, header := range []string{`for`, `for="192.0.2.60`, `=x`} {
+		req := newRequest(http.MethodGet, "/", "", "")
+		req.Header.Set("Forwarded", header)
+		var dest headers
+		if err := BindHeaders(req, &dest); !errors.Is(err, ErrInvalidFormat) {
+			t.Errorf("%q: got error %v, want ErrInvalidFormat", header, err)
+		}
+	}
+}
+
+func TestBindHeaders_FormatLink(t *testing.T) {
+	type relation string
+	type headers struct {
+		Links map[string]string    `header:"Link" format:"link"`
+		Typed *map[relation]string `header:"Link" format:"link"`
+	}
+	req := newRequest(http.MethodGet, "/", "", "")
+	req.Header.Add("Link", `<https://api.example.com/items?page=2>; rel="next", <https://api.example.com/items?page=9>; REL=last`)
+	req.Header.Add("Link", `<https://api.example.com/items?page=1>; title="a;b"; rel="first prev", <https://other/next>; rel=next, <https://api.example.com/docs>`)
+	var dest headers
+	if err := BindHeaders(req, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]string{
+		"next":  "https://api.example.com/items?page=2",
+		"last":  "https://api.example.com/items?page=9",
+		"first": "https://api.example.com/items?page=1",
+		"prev":  "https://api.example.com/items?page=1",
+	}
+	if !reflect.DeepEqual(dest.Links, want) {
+		t.Errorf("Links = %v, want %v", dest.Links, want)
+	}
+	if dest.Typed == nil || (*dest.Typed)["last"] != want["last"] {
+		t.Errorf("Typed = %v", dest.Typed)
+	}
+
+	for _, header := range []string{`https://a; rel=next`, `<https://a; rel=next`, `<https://a> rel=next`, `<https://a>; rel="next`} {
+		req := newRequest(http.MethodGet, "/", "", "")
+		req.Header.Set("Link", header)
+		var dest headers
+		if err := BindHeaders(req, &dest); !errors.Is(err, ErrInvalidFormat) {
+			t.Errorf("%q: got error %v, want ErrInvalidFormat", header, err)
+		}
+	}
+	var wrongType struct {
+		Links []string `header:"Link" format:"link"`
+	}
+	req = newRequest(http.MethodGet, "/", "", "")
+	req.Header.Set("Link", `<https://a>; rel=next`)
+	if err := BindHeaders(req, &wrongType); err == nil {
+		t.Error("slice field: expected error")
+	}
+}
+
+func TestBindHeaders_FormatOrderedKV(t *testing.T) {
+	type pair struct{ Key, Value string }
+	type headers struct {
+		Features []pair  `header:"X-Features" format:"ordered-kv"`
+		Ptr      *[]pair `header:"X-Features" format:"ordered-kv"`
+	}
+	req := newRequest(http.MethodGet, "/", "", "")
+	req.Header.Add("X-Features", " a = 1 ;b=2;; flag")
+	req.Header.Add("X-Features", "a=3")
+	var dest headers
+	if err := BindHeaders(req, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []pair{{"a", "1"}, {"b", "2"}, {"flag", ""}, {"a", "3"}}
+	if !reflect.DeepEqual(dest.Features, want) || dest.Ptr == nil || !reflect.DeepEqual(*dest.Ptr, want) {
+		t.Errorf("got %+v, want %+v", dest, want)
+	}
+
+	for _, field := range []interface{}{
+		&struct {
+			V map[string]string `header:"X-Features" format:"ordered-kv"`
+		}{},
+		&struct {
+			V []struct{ Name, Value string } `header:"X-Features" format:"ordered-kv"`
+		}{},
+		&struct {
+			V []struct {
+				Key   string
+				Value int
+			} `header:"X-Features" format:"ordered-kv"`
+		}{},
+	} {
+		if err := BindHeaders(req, field); err == nil {
+			t.Errorf("%T: expected error", field)
+		}
+	}
+}
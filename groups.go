@@ -0,0 +1,98 @@
+package binding
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ErrFieldGroup is matched by errors returned when fields tagged with the same `group` do not satisfy its `groupmode`
+var ErrFieldGroup = errors.New("field group condition not met")
+
+// fieldGroup collects fields of a `group` tag
+type fieldGroup struct {
+	mode    string
+	members []string
+	set     []string
+}
+
+// checkFieldGroups validates presence of fields tagged `group:"<name>"` after binding. Field is set when input value
+// was bound into it or it is not zero (i.e. body decoded into it). Mode comes from `groupmode` tag of any field of the
+// group: `exactlyone` (default), `atleastone` or `atmostone`.
+// Groups are only checked by calls binding objects from all their sources, see bindState.groups.
+func checkFieldGroups(s *bindState, val reflect.Value) error {
+	groups := map[string]*fieldGroup{}
+	if err := collectFieldGroups(s, "", val, groups); err != nil {
+		return err
+	}
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		g := groups[name]
+		var condition string
+		switch mode := g.mode; {
+		case (mode == "" || mode == "exactlyone") && len(g.set) != 1:
+			condition = "exactly one"
+		case mode == "atleastone" && len(g.set) == 0:
+			condition = "at least one"
+		case mode == "atmostone" && len(g.set) > 1:
+			condition = "at most one"
+		default:
+			continue
+		}
+		return fmt.Errorf("%w: group %q requires %s of [%s] to be set, got %d [%s]", ErrFieldGroup, name, condition,
+			strings.Join(g.members, ", "), len(g.set), strings.Join(g.set, ", "))
+	}
+	return nil
+}
+
+func collectFieldGroups(s *bindState, path string, val reflect.Value, groups map[string]*fieldGroup) error {
+	typ := val.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		typeField := typ.Field(i)
+		structField := val.Field(i)
+		if !typeField.IsExported() {
+			continue
+		}
+		fPath := fieldPath(path, typeField)
+
+		name := typeField.Tag.Get("group")
+		if name == "" {
+			if structField.Kind() == reflect.Ptr && !structField.IsNil() {
+				structField = structField.Elem()
+			}
+			if structField.Kind() == reflect.Struct {
+				if err := collectFieldGroups(s, fPath, structField, groups); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		g := groups[name]
+		if g == nil {
+			g = &fieldGroup{}
+			groups[name] = g
+		}
+		if mode := typeField.Tag.Get("groupmode"); mode != "" {
+			switch {
+			case mode != "exactlyone" && mode != "atleastone" && mode != "atmostone":
+				return fmt.Errorf("invalid groupmode %q of field %s", mode, fPath)
+			case g.mode != "" && g.mode != mode:
+				return fmt.Errorf("conflicting groupmode %q of field %s, group %q is %q", mode, fPath, name, g.mode)
+			}
+			g.mode = mode
+		}
+		g.members = append(g.members, fPath)
+		if s.present[fPath] || !structField.IsZero() {
+			g.set = append(g.set, fPath)
+		}
+	}
+	return nil
+}
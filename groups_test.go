@@ -0,0 +1,45 @@
+package binding
+
+import (
+	"errors"
+	"testing"
+)
+
+type groupedAuth struct {
+	Token  string `header:"Authorization" group:"auth"`
+	APIKey string `query:"api_key" group:"auth"`
+}
+
+func TestBindHeaders_SkipsFieldGroups(t *testing.T) {
+	req := newRequest("GET", "/?api_key=k", "", "")
+	var dest groupedAuth
+	if err := BindHeaders(req, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := BindQueryParams(req, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dest.APIKey != "k" {
+		t.Errorf("APIKey = %q, want k", dest.APIKey)
+	}
+}
+
+func TestBind_ChecksFieldGroups(t *testing.T) {
+	req := newRequest("GET", "/?api_key=k", "", "")
+	req.Header.Set("Authorization", "t")
+	var dest groupedAuth
+	// headers are not bound by Bind by default, so only api_key is set
+	if err := Bind(req, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	dest = groupedAuth{}
+	if err := BindWith(req, &dest, SourceHeader, SourceQuery); !errors.Is(err, ErrFieldGroup) {
+		t.Fatalf("BindWith error = %v, want ErrFieldGroup", err)
+	}
+
+	req = newRequest("GET", "/", "", "")
+	dest = groupedAuth{}
+	if err := Bind(req, &dest); !errors.Is(err, ErrFieldGroup) {
+		t.Fatalf("Bind without members error = %v, want ErrFieldGroup", err)
+	}
+}
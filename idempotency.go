@@ -0,0 +1,59 @@
+package binding
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+)
+
+// IdempotencyHash returns stable SHA-256 hex hash of bound struct fields tagged `idempotent:"true"`, including fields
+// of nested structs. It is meant to be called after binding to build idempotency keys, requests that bound the same
+// values into these fields have the same hash. Each field contributes its path and JSON encoding of its value (map
+// keys are sorted by encoding/json), so hash does not depend on input order of the request.
+func IdempotencyHash(i interface{}) (string, error) {
+	val := reflect.ValueOf(i)
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return "", fmt.Errorf("idempotency hash of nil %T", i)
+		}
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return "", fmt.Errorf("idempotency hash requires a struct, got %T", i)
+	}
+	h := sha256.New()
+	if err := hashIdempotentFields(h, "", val); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func hashIdempotentFields(h io.Writer, path string, val reflect.Value) error {
+	typ := val.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		typeField := typ.Field(i)
+		if !typeField.IsExported() {
+			continue
+		}
+		fPath := fieldPath(path, typeField)
+		if idempotent, _ := strconv.ParseBool(typeField.Tag.Get("idempotent")); !idempotent {
+			if val.Field(i).Kind() == reflect.Struct {
+				if err := hashIdempotentFields(h, fPath, val.Field(i)); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		value, err := json.Marshal(val.Field(i).Interface())
+		if err != nil {
+			return fmt.Errorf("idempotency hash of field %s: %w", fPath, err)
+		}
+		// length prefixes keep boundaries between fields unambiguous
+		fmt.Fprintf(h, "%d:%s%d:%s", len(fPath), fPath, len(value), value)
+	}
+	return nil
+}
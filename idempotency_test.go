@@ -0,0 +1,64 @@
+package binding
+
+import (
+	"net/http"
+	"testing"
+)
+
+type payment struct {
+	Amount   int               `json:"amount" idempotent:"true"`
+	Currency string            `json:"currency" idempotent:"true"`
+	Labels   map[string]string `json:"labels" idempotent:"true"`
+	Note     string            `json:"note"`
+	Card     struct {
+		Last4 string `json:"last4" idempotent:"true"`
+	} `json:"card"`
+}
+
+func TestIdempotencyHash(t *testing.T) {
+	hash := func(body string) string {
+		t.Helper()
+		var p payment
+		if err := Bind(newRequest(http.MethodPost, "/", MIMEApplicationJSON, body), &p); err != nil {
+			t.Fatal(err)
+		}
+		h, err := IdempotencyHash(&p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return h
+	}
+
+	base := hash(`{"amount":100,"currency":"EUR","labels":{"a":"1","b":"2"},"note":"x","card":{"last4":"4242"}}`)
+	if len(base) != 64 {
+		t.Errorf("hash %q is not SHA-256 hex", base)
+	}
+	same := hash(`{"card":{"last4":"4242"},"note":"other","labels":{"b":"2","a":"1"},"currency":"EUR","amount":100}`)
+	if same != base {
+		t.Error("hash depends on input order or non-idempotent fields")
+	}
+	for _, body := range []string{
+		`{"amount":101,"currency":"EUR","labels":{"a":"1","b":"2"},"card":{"last4":"4242"}}`,
+		`{"amount":100,"currency":"EUR","labels":{"a":"1"},"card":{"last4":"4242"}}`,
+		`{"amount":100,"currency":"EUR","labels":{"a":"1","b":"2"},"card":{"last4":"0000"}}`,
+	} {
+		if hash(body) == base {
+			t.Errorf("%s: same hash for different idempotent values", body)
+		}
+	}
+}
+
+func TestIdempotencyHash_Invalid(t *testing.T) {
+	var nilPayment *payment
+	for _, i := range []interface{}{nilPayment, 42, nil} {
+		if _, err := IdempotencyHash(i); err == nil {
+			t.Errorf("%T: expected error", i)
+		}
+	}
+	var unsupported struct {
+		Fn func() `idempotent:"true"`
+	}
+	if _, err := IdempotencyHash(&unsupported); err == nil {
+		t.Error("expected error of field JSON can not encode")
+	}
+}
@@ -0,0 +1,14 @@
+package binding
+
+import "fmt"
+
+// BindIntrospection binds OAuth 2.0 token introspection response (RFC 7662), as provided by middleware, into fields
+// tagged with `introspect:"<member>"` i.e. `introspect:"client_id"`. Members are converted like by BindAnyMap, space
+// separated `scope` binds into []string field with `delim:" "` tag. Response of inactive token (`"active": false`)
+// returns error matching ErrInvalidToken and nothing is bound.
+func BindIntrospection(result map[string]interface{}, i interface{}) error {
+	if active, ok := result["active"]; ok && active != true {
+		return fmt.Errorf("%w: token is not active", ErrInvalidToken)
+	}
+	return BindAnyMap(result, i, "introspect")
+}
@@ -0,0 +1,38 @@
+package binding
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestBindIntrospection(t *testing.T) {
+	type token struct {
+		Active   bool     `introspect:"active"`
+		Scopes   []string `introspect:"scope" delim:" "`
+		ClientID string   `introspect:"client_id"`
+		Expiry   int64    `introspect:"exp"`
+	}
+	result := map[string]interface{}{
+		"active":    true,
+		"scope":     "read write admin",
+		"client_id": "cli",
+		"exp":       float64(1700000000),
+	}
+	var tok token
+	if err := BindIntrospection(result, &tok); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := token{Active: true, Scopes: []string{"read", "write", "admin"}, ClientID: "cli", Expiry: 1700000000}
+	if !reflect.DeepEqual(tok, want) {
+		t.Errorf("got %+v, want %+v", tok, want)
+	}
+
+	for _, active := range []interface{}{false, "true", nil} {
+		var inactive token
+		err := BindIntrospection(map[string]interface{}{"active": active, "client_id": "cli"}, &inactive)
+		if !errors.Is(err, ErrInvalidToken) || inactive.ClientID != "" {
+			t.Errorf("active %v: got %+v, error %v, want ErrInvalidToken", active, inactive, err)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package binding
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// DefaultMaxJSONDepth is nesting depth of JSON bodies allowed when DefaultBinder.MaxJSONDepth is zero
+const DefaultMaxJSONDepth = 1000
+
+// ErrJSONTooDeep is returned for JSON bodies nested deeper than DefaultBinder.MaxJSONDepth
+var ErrJSONTooDeep = errors.New("json body nested too deep")
+
+// jsonDepthReader fails reading JSON nested deeper than max objects and arrays. Depth is tracked on the bytes passing
+// through, so the decoder reading from it stops at the first one exceeding the limit without buffering the body.
+type jsonDepthReader struct {
+	r        io.Reader
+	max      int
+	depth    int
+	inString bool
+	escaped  bool
+}
+
+func (d *jsonDepthReader) Read(p []byte) (int, error) {
+	n, err := d.r.Read(p)
+	for _, c := range p[:n] {
+		switch {
+		case d.inString && d.escaped:
+			d.escaped = false
+		case d.inString && c == '\\':
+			d.escaped = true
+		case c == '"':
+			d.inString = !d.inString
+		case d.inString:
+		case c == '{' || c == '[':
+			if d.depth++; d.depth > d.max {
+				return 0, fmt.Errorf("%w: exceeds maximum depth %d", ErrJSONTooDeep, d.max)
+			}
+		case c == '}' || c == ']':
+			d.depth--
+		}
+	}
+	return n, err
+}
+
+// limitJSONDepth returns r failing reads of JSON nested deeper than DefaultBinder.MaxJSONDepth
+func (b *DefaultBinder) limitJSONDepth(r io.Reader) io.Reader {
+	max := b.MaxJSONDepth
+	if max < 0 {
+		return r
+	}
+	if max == 0 {
+		max = DefaultMaxJSONDepth
+	}
+	return &jsonDepthReader{r: r, max: max}
+}
@@ -0,0 +1,49 @@
+package binding
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestBindBody_MaxJSONDepth(t *testing.T) {
+	type doc struct {
+		V interface{} `json:"v"`
+	}
+	tests := []struct {
+		name    string
+		depth   int
+		body    string
+		tooDeep bool
+	}{
+		{"array at limit", 3, `{"v":[[1]]}`, false},
+		{"array over limit", 3, `{"v":[[[1]]]}`, true},
+		{"object at limit", 3, `{"v":{"a":{"b":1}}}`, false},
+		{"object over limit", 3, `{"v":{"a":{"b":{"c":1}}}}`, true},
+		{"siblings do not add up", 3, `{"v":[[1],{"a":2},[3]]}`, false},
+		{"brackets in strings", 3, `{"v":[["[[{{\"]]"]]}`, false},
+		{"default at limit", 0, `{"v":` + strings.Repeat("[", DefaultMaxJSONDepth-1) + strings.Repeat("]", DefaultMaxJSONDepth-1) + `}`, false},
+		{"default over limit", 0, `{"v":` + strings.Repeat("[", DefaultMaxJSONDepth) + strings.Repeat("]", DefaultMaxJSONDepth) + `}`, true},
+		{"disabled", -1, `{"v":` + strings.Repeat("[", DefaultMaxJSONDepth) + strings.Repeat("]", DefaultMaxJSONDepth) + `}`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBinder(WithMaxJSONDepth(tt.depth))
+			var d doc
+			err := b.BindBody(newRequest(http.MethodPost, "/", "application/json", tt.body), &d)
+			if tt.tooDeep {
+				if !errors.Is(err, ErrJSONTooDeep) {
+					t.Fatalf("got error %v, want ErrJSONTooDeep", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d.V == nil {
+				t.Error("value not bound")
+			}
+		})
+	}
+}
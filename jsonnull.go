@@ -0,0 +1,92 @@
+package binding
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// checkJSONNullsStream checks every JSON value of body, which can be a stream of concatenated objects
+func checkJSONNullsStream(body []byte, t reflect.Type) error {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	for {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			// end of body, malformed input is reported by decoding into destination
+			return nil
+		}
+		if err := checkJSONNulls("", raw, t); err != nil {
+			return err
+		}
+	}
+}
+
+// checkJSONNulls reports explicit JSON null given for non-pointer fields tagged `json:"<name>,required"` of struct
+// type t, except fields readonly for body. Nested objects are checked against their struct fields.
+func checkJSONNulls(path string, data []byte, t reflect.Type) error {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		// not an object, decoding already handled it
+		return nil
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		typeField := t.Field(i)
+		name, opts := parseTag(typeField.Tag.Get("json"))
+		// like encoding/json, fields of untagged embedded struct are promoted even when its type is unexported
+		promoted := typeField.Anonymous && name == "" && typeField.Type.Kind() == reflect.Struct
+		if name == "-" || !typeField.IsExported() && !promoted || isReadonly(typeField, "body") {
+			// body can not set readonly fields, so their nulls are never bound
+			continue
+		}
+		if name == "" {
+			if typeField.Anonymous {
+				// fields of embedded struct are promoted into the same object
+				if err := checkJSONNulls(path, data, typeField.Type); err != nil {
+					return err
+				}
+				continue
+			}
+			name = typeField.Name
+		}
+		raw, ok := lookupRawJSON(object, name)
+		if !ok {
+			continue
+		}
+		if bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+			if opts.contains("required") && typeField.Type.Kind() != reflect.Ptr {
+				return &BindingError{
+					Field:         fieldPath(path, typeField),
+					Values:        []string{"null"},
+					Message:       "required field value is null",
+					InternalError: ErrRequired,
+				}
+			}
+			continue
+		}
+		if err := checkJSONNulls(fieldPath(path, typeField), raw, typeField.Type); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lookupRawJSON finds object key like encoding/json does, exact match first and then case-insensitively
+func lookupRawJSON(object map[string]json.RawMessage, name string) (json.RawMessage, bool) {
+	if raw, ok := object[name]; ok {
+		return raw, true
+	}
+	for k, raw := range object {
+		if strings.EqualFold(k, name) {
+			return raw, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,66 @@
+package binding
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+type nullPayload struct {
+	Name    string  `json:"name,required"`
+	Nick    *string `json:"nick,required"`
+	Note    string  `json:"note"`
+	Address struct {
+		City string `json:"city,required"`
+	} `json:"address"`
+}
+
+func TestBindBody_StrictJSONNull(t *testing.T) {
+	b := &DefaultBinder{StrictJSONNull: true}
+	testCases := []struct {
+		body  string
+		field string
+	}{
+		{`{"name":null}`, "Name"},
+		{`{"NAME": null}`, "Name"},
+		{`{"name":"a","address":{"city":null}}`, "Address.City"},
+	}
+	for _, tc := range testCases {
+		var dest nullPayload
+		err := b.BindBody(newRequest(http.MethodPost, "/", MIMEApplicationJSON, tc.body), &dest)
+		var be *BindingError
+		if !errors.Is(err, ErrRequired) || !errors.As(err, &be) || be.Field != tc.field {
+			t.Errorf("%s: got error %v, want ErrRequired of %s", tc.body, err, tc.field)
+		}
+	}
+
+	var dest nullPayload
+	body := `{"name":"a","nick":null,"note":null,"address":null}`
+	if err := b.BindBody(newRequest(http.MethodPost, "/", MIMEApplicationJSON, body), &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dest.Name != "a" || dest.Nick != nil {
+		t.Errorf("got %+v", dest)
+	}
+
+	if err := BindBody(newRequest(http.MethodPost, "/", MIMEApplicationJSON, `{"name":null}`), &dest); err != nil {
+		t.Errorf("lenient by default: unexpected error: %v", err)
+	}
+}
+
+type nullAudit struct {
+	By string `json:"by,required"`
+}
+
+func TestBindBody_StrictJSONNullEmbedded(t *testing.T) {
+	var dest struct {
+		nullAudit
+		Name string `json:"name,required"`
+	}
+	b := &DefaultBinder{StrictJSONNull: true}
+	err := b.BindBody(newRequest(http.MethodPost, "/", MIMEApplicationJSON, `{"name":"a","by":null}`), &dest)
+	var be *BindingError
+	if !errors.Is(err, ErrRequired) || !errors.As(err, &be) || be.Field != "By" {
+		t.Errorf("got error %v, want ErrRequired of By", err)
+	}
+}
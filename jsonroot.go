@@ -0,0 +1,32 @@
+package binding
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrJSONRootNotFound is returned for JSON bodies without the object at DefaultBinder.JSONRoot
+var ErrJSONRootNotFound = errors.New("json root not found")
+
+// jsonRoot returns sub-tree of JSON body at dot separated root path i.e. `data` or `response.data`. Every segment must
+// name a key of an object.
+func jsonRoot(body []byte, root string) ([]byte, error) {
+	for _, key := range strings.Split(root, ".") {
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(body, &object); err != nil {
+			var syntaxErr *json.SyntaxError
+			if errors.As(err, &syntaxErr) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%w: %q is not an object", ErrJSONRootNotFound, root)
+		}
+		sub, ok := object[key]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrJSONRootNotFound, root)
+		}
+		body = sub
+	}
+	return body, nil
+}
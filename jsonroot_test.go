@@ -0,0 +1,49 @@
+package binding
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestBindBody_JSONRoot(t *testing.T) {
+	type user struct {
+		Name string `json:"name"`
+	}
+	testCases := []struct {
+		root string
+		body string
+		want string
+		err  error
+	}{
+		{"data", `{"data":{"name":"n"},"meta":{"page":1}}`, "n", nil},
+		{"response.data", `{"response":{"data":{"name":"deep"}}}`, "deep", nil},
+		{"data", `{"meta":{}}`, "", ErrJSONRootNotFound},
+		{"response.data", `{"response":[1]}`, "", ErrJSONRootNotFound},
+		{"data", `{"data":`, "", nil},
+	}
+	for _, tc := range testCases {
+		b := NewBinder(WithJSONRoot(tc.root), WithStrictJSON(true))
+		var u user
+		err := b.BindBody(newRequest(http.MethodPost, "/", MIMEApplicationJSON, tc.body), &u)
+		switch {
+		case tc.err != nil:
+			if !errors.Is(err, tc.err) {
+				t.Errorf("%s: got error %v, want %v", tc.body, err, tc.err)
+			}
+		case tc.want == "":
+			if err == nil || errors.Is(err, ErrJSONRootNotFound) {
+				t.Errorf("%s: got error %v, want syntax error", tc.body, err)
+			}
+		case err != nil || u.Name != tc.want:
+			t.Errorf("%s: got %+v, error %v", tc.body, u, err)
+		}
+	}
+
+	// other media types are not affected
+	var u user
+	b := NewBinder(WithJSONRoot("data"))
+	if err := b.BindBody(newRequest(http.MethodPost, "/", MIMEApplicationXML, `<user><name>x</name></user>`), &u); err != nil {
+		t.Errorf("XML body: unexpected error: %v", err)
+	}
+}
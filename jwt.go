@@ -0,0 +1,66 @@
+package binding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ErrInvalidToken is matched by errors of BindJWT when bearer token fails verification
+var ErrInvalidToken = errors.New("invalid token")
+
+// JWTVerifier verifies JWT and returns its claims
+type JWTVerifier func(ctx context.Context, token string) (map[string]interface{}, error)
+
+var (
+	jwtVerifierMu sync.RWMutex
+	jwtVerifier   JWTVerifier
+)
+
+// RegisterJWTVerifier registers verifier used by BindJWT. This package does not import any JWT library, wrap one
+// checking signature and standard claims (exp, nbf, aud ...) of the token.
+func RegisterJWTVerifier(fn JWTVerifier) {
+	jwtVerifierMu.Lock()
+	defer jwtVerifierMu.Unlock()
+	jwtVerifier = fn
+}
+
+// BindJWT verifies bearer token from Authorization header with verifier registered by RegisterJWTVerifier and binds its
+// claims into fields tagged with `jwt:"<claim>"` i.e. `jwt:"sub"`. Claims are converted like by BindAnyMap. Binds
+// nothing when request has no bearer token, failed verification returns error matching ErrInvalidToken.
+func BindJWT(r *http.Request, i interface{}) error {
+	return defaultBinder.BindJWT(r, i)
+}
+
+// BindJWT verifies bearer token and binds its claims to bindable object. See package level BindJWT.
+func (b *DefaultBinder) BindJWT(r *http.Request, i interface{}) error {
+	s := b.newState(r)
+	return b.run(s, i, func() error { return b.bindJWT(r, i, s) })
+}
+
+func (b *DefaultBinder) bindJWT(r *http.Request, i interface{}, s *bindState) error {
+	scheme, token, ok := strings.Cut(r.Header.Get(HeaderAuthorization), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return nil
+	}
+	jwtVerifierMu.RLock()
+	verify := jwtVerifier
+	jwtVerifierMu.RUnlock()
+	if verify == nil {
+		return errors.New("jwt verifier is not registered")
+	}
+
+	claims, err := verify(s.ctx, token)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	val := reflect.ValueOf(i)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("binding element must be a pointer to struct, got %T", i)
+	}
+	return bindAnyMap(s, "", claims, val.Elem(), "jwt")
+}
@@ -0,0 +1,64 @@
+package binding
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestBindJWT(t *testing.T) {
+	type identity struct {
+		Subject string   `jwt:"sub"`
+		Roles   []string `jwt:"roles"`
+		Expires int64    `jwt:"exp"`
+	}
+	RegisterJWTVerifier(func(ctx context.Context, token string) (map[string]interface{}, error) {
+		if token != "valid" {
+			return nil, errors.New("bad signature")
+		}
+		return map[string]interface{}{
+			"sub":   "user-1",
+			"roles": []interface{}{"admin", "dev"},
+			"exp":   float64(1700000000),
+		}, nil
+	})
+	t.Cleanup(func() { RegisterJWTVerifier(nil) })
+
+	req := newRequest(http.MethodGet, "/", "", "")
+	req.Header.Set(HeaderAuthorization, "bearer valid")
+	var id identity
+	if err := BindJWT(req, &id); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := identity{Subject: "user-1", Roles: []string{"admin", "dev"}, Expires: 1700000000}
+	if !reflect.DeepEqual(id, want) {
+		t.Errorf("got %+v, want %+v", id, want)
+	}
+
+	req.Header.Set(HeaderAuthorization, "Bearer forged")
+	if err := BindJWT(req, &identity{}); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("forged token: got error %v, want ErrInvalidToken", err)
+	}
+
+	// no bearer token binds nothing
+	for _, auth := range []string{"", "Basic dXNlcjpwYXNz", "Bearer "} {
+		req.Header.Set(HeaderAuthorization, auth)
+		var none identity
+		if err := BindJWT(req, &none); err != nil || !reflect.DeepEqual(none, identity{}) {
+			t.Errorf("Authorization %q: got %+v, error %v", auth, none, err)
+		}
+	}
+}
+
+func TestBindJWT_NoVerifier(t *testing.T) {
+	req := newRequest(http.MethodGet, "/", "", "")
+	req.Header.Set(HeaderAuthorization, "Bearer valid")
+	var dest struct {
+		Subject string `jwt:"sub"`
+	}
+	if err := BindJWT(req, &dest); err == nil {
+		t.Error("expected error without registered verifier")
+	}
+}
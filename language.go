@@ -0,0 +1,60 @@
+package binding
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// LanguageMatcher returns the best match of supported languages for `Accept-Language` header value, i.e. a
+// golang.org/x/text/language.Tag. Returned value is bound into field tagged with `format:"language"`, so it must be
+// assignable to the field type.
+type LanguageMatcher func(acceptLanguage string) (interface{}, error)
+
+var (
+	languageMatcherMu sync.RWMutex
+	languageMatcher   LanguageMatcher
+)
+
+// RegisterLanguageMatcher registers matcher used by fields tagged with `format:"language"`. This package does not
+// import golang.org/x/text to avoid the dependency, wrap its matcher of the supported languages instead:
+//
+//	matcher := language.NewMatcher([]language.Tag{language.English, language.German})
+//	binding.RegisterLanguageMatcher(func(accept string) (interface{}, error) {
+//		tags, _, err := language.ParseAcceptLanguage(accept)
+//		if err != nil {
+//			return nil, err
+//		}
+//		tag, _, _ := matcher.Match(tags...)
+//		return tag, nil
+//	})
+//
+// Nil fn removes the matcher, `format:"language"` fields are then left untouched.
+func RegisterLanguageMatcher(fn LanguageMatcher) {
+	languageMatcherMu.Lock()
+	defer languageMatcherMu.Unlock()
+	languageMatcher = fn
+}
+
+// formatLanguage binds best match of `Accept-Language` value returned by the registered LanguageMatcher into the
+// field. Repeated headers are combined into one list. Without registered matcher the field is not bound.
+func formatLanguage(_ *bindState, in fieldInput, field reflect.Value) error {
+	languageMatcherMu.RLock()
+	match := languageMatcher
+	languageMatcherMu.RUnlock()
+	if match == nil {
+		return nil
+	}
+	tag, err := match(strings.Join(in.values, ", "))
+	if err != nil {
+		return fmt.Errorf("%w: malformed Accept-Language %q: %v", ErrInvalidFormat, in.values, err)
+	}
+	field = allocPointer(field)
+	v := reflect.ValueOf(tag)
+	if !v.IsValid() || !v.Type().AssignableTo(field.Type()) {
+		return fmt.Errorf("language matcher result %T can not be bound into field of type %v", tag, field.Type())
+	}
+	field.Set(v)
+	return nil
+}
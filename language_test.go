@@ -0,0 +1,65 @@
+package binding
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// testLang stands in for language.Tag of golang.org/x/text
+type testLang string
+
+func TestBindHeaders_FormatLanguage(t *testing.T) {
+	type headers struct {
+		Lang testLang  `header:"Accept-Language" format:"language"`
+		Ptr  *testLang `header:"Accept-Language" format:"language"`
+	}
+	bind := func(values ...string) (headers, error) {
+		req := newRequest(http.MethodGet, "/", "", "")
+		for _, v := range values {
+			req.Header.Add("Accept-Language", v)
+		}
+		d := headers{Lang: "untouched"}
+		return d, BindHeaders(req, &d)
+	}
+
+	if d, err := bind("de"); err != nil || d.Lang != "untouched" {
+		t.Errorf("without matcher: got %+v, error %v", d, err)
+	}
+
+	supported := map[string]bool{"en": true, "de": true}
+	RegisterLanguageMatcher(func(accept string) (interface{}, error) {
+		for _, item := range strings.Split(accept, ",") {
+			tag, _, _ := strings.Cut(strings.TrimSpace(item), ";")
+			if tag == "" || strings.ContainsAny(tag, " =") {
+				return nil, errors.New("malformed language tag")
+			}
+			if supported[tag] {
+				return testLang(tag), nil
+			}
+		}
+		return testLang("en"), nil
+	})
+	defer RegisterLanguageMatcher(nil)
+
+	d, err := bind("fr-CH, fr;q=0.9", "de;q=0.7")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.Lang != "de" || d.Ptr == nil || *d.Ptr != "de" {
+		t.Errorf("got %+v", d)
+	}
+	if _, err := bind("= x, de"); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("matcher error: got error %v, want ErrInvalidFormat", err)
+	}
+
+	var wrongType struct {
+		Lang int `header:"Accept-Language" format:"language"`
+	}
+	req := newRequest(http.MethodGet, "/", "", "")
+	req.Header.Set("Accept-Language", "de")
+	if err := BindHeaders(req, &wrongType); err == nil {
+		t.Error("unassignable field: expected error")
+	}
+}
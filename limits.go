@@ -0,0 +1,52 @@
+package binding
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"unicode/utf8"
+)
+
+// ErrValueLimit is matched by errors of fields whose input exceeds their `maxitems` or `maxbytes` tag. Map it to 413.
+var ErrValueLimit = errors.New("input value limit exceeded")
+
+// checkValueLimits enforces `maxitems:"<n>"` (number of values) and `maxbytes:"<n>"` (summed length of raw values in
+// bytes, not runes) tags of the field
+func checkValueLimits(name string, values []string, typeField reflect.StructField) error {
+	if limit, ok := typeField.Tag.Lookup("maxitems"); ok {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			return fmt.Errorf("invalid maxitems %q of field %s", limit, typeField.Name)
+		}
+		if len(values) > n {
+			return &BindingError{
+				Field:         name,
+				Values:        values,
+				Message:       fmt.Sprintf("field has %d values, at most %d allowed", len(values), n),
+				InternalError: ErrValueLimit,
+			}
+		}
+	}
+	if limit, ok := typeField.Tag.Lookup("maxbytes"); ok {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			return fmt.Errorf("invalid maxbytes %q of field %s", limit, typeField.Name)
+		}
+		size, runes := 0, 0
+		for _, v := range values {
+			size += len(v)
+			runes += utf8.RuneCountInString(v)
+		}
+		if size > n {
+			// limit is in bytes, rune count tells apart multibyte input that is short in characters
+			return &BindingError{
+				Field:         name,
+				Values:        values,
+				Message:       fmt.Sprintf("field values have %d bytes (%d characters), at most %d bytes allowed", size, runes, n),
+				InternalError: ErrValueLimit,
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package binding
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestBindQueryParams_ValueLimits(t *testing.T) {
+	type query struct {
+		Tags []string `query:"tags" maxitems:"2" maxbytes:"6"`
+		IDs  []int    `query:"ids" delim:"," maxitems:"1"`
+	}
+	testCases := []struct {
+		name   string
+		target string
+		field  string
+	}{
+		{"within limits", "/?tags=abc&tags=def&ids=1,2,3", ""},
+		{"too many items", "/?tags=a&tags=b&tags=c", "tags"},
+		{"too many bytes", "/?tags=abcd&tags=efg", "tags"},
+		{"limit checked before split", "/?ids=1&ids=2", "ids"},
+	}
+	for _, tc := range testCases {
+		var q query
+		err := BindQueryParams(newRequest(http.MethodGet, tc.target, "", ""), &q)
+		if tc.field == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", tc.name, err)
+			}
+			continue
+		}
+		var be *BindingError
+		if !errors.Is(err, ErrValueLimit) || !errors.As(err, &be) || be.Field != tc.field {
+			t.Errorf("%s: got error %v, want ErrValueLimit of %s", tc.name, err, tc.field)
+		}
+	}
+
+	var invalid struct {
+		Tags []string `query:"tags" maxitems:"many"`
+	}
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?tags=a", "", ""), &invalid); err == nil || errors.Is(err, ErrValueLimit) {
+		t.Errorf("invalid maxitems: got error %v", err)
+	}
+}
+
+func TestBindQueryParams_MaxBytesCountsBytes(t *testing.T) {
+	var q struct {
+		Name string `query:"name" maxbytes:"4"`
+	}
+	// "日本" is 2 characters and 6 bytes
+	err := BindQueryParams(newRequest(http.MethodGet, "/?name=%E6%97%A5%E6%9C%AC", "", ""), &q)
+	var be *BindingError
+	if !errors.Is(err, ErrValueLimit) || !errors.As(err, &be) {
+		t.Fatalf("got error %v, want ErrValueLimit", err)
+	}
+	if want := "field values have 6 bytes (2 characters), at most 4 bytes allowed"; be.Message != want {
+		t.Errorf("Message = %q, want %q", be.Message, want)
+	}
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?name=abcd", "", ""), &q); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package binding
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// LocaleParser converts locale formatted input values. It is pluggable so this package does not depend on any i18n
+// library. Locale is the field `locale` tag or DefaultBinder.Locale, i.e. `de-DE`.
+type LocaleParser interface {
+	// Number returns locale formatted number as plain Go number literal, i.e. `1234.5` for `1.234,5` in `de-DE`
+	Number(locale string, value string) (string, error)
+	// Time parses locale formatted date or time
+	Time(locale string, value string) (time.Time, error)
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// fieldLocale returns locale of the field, empty when input is not locale specific or there is no parser to handle it
+func (s *bindState) fieldLocale(in *fieldInput) string {
+	if s.binder.LocaleParser == nil {
+		return ""
+	}
+	if locale := in.field.Tag.Get("locale"); locale != "" {
+		return locale
+	}
+	return s.binder.Locale
+}
+
+// setLocaleTime parses value with LocaleParser into time.Time (or *time.Time) field. Reports false when field has no
+// locale.
+func (s *bindState) setLocaleTime(in *fieldInput, val string, field reflect.Value) (bool, error) {
+	locale := s.fieldLocale(in)
+	if locale == "" {
+		return false, nil
+	}
+	t, err := s.binder.LocaleParser.Time(locale, val)
+	if err != nil {
+		return true, err
+	}
+	allocPointer(field).Set(reflect.ValueOf(t))
+	return true, nil
+}
+
+// localeNumber returns value converted from locale format for numeric fields with locale
+func (s *bindState) localeNumber(in *fieldInput, val string) (string, error) {
+	locale := s.fieldLocale(in)
+	if locale == "" || val == "" {
+		return s.binder.separatedNumber(val), nil
+	}
+	return s.binder.LocaleParser.Number(locale, val)
+}
+
+// separatedNumber returns value with DefaultBinder.ThousandsSeparator removed and DefaultBinder.DecimalSeparator
+// replaced by `.`
+func (b *DefaultBinder) separatedNumber(val string) string {
+	if b.ThousandsSeparator != "" {
+		val = strings.ReplaceAll(val, b.ThousandsSeparator, "")
+	}
+	if b.DecimalSeparator != "" && b.DecimalSeparator != "." {
+		val = strings.Replace(val, b.DecimalSeparator, ".", 1)
+	}
+	return val
+}
+
+func isNumberKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package binding
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testLocaleParser knows `de-DE` numbers and dates and `en-US` numbers
+type testLocaleParser struct{}
+
+func (testLocaleParser) Number(locale string, value string) (string, error) {
+	switch locale {
+	case "de-DE":
+		return strings.ReplaceAll(strings.ReplaceAll(value, ".", ""), ",", "."), nil
+	case "en-US":
+		return strings.ReplaceAll(value, ",", ""), nil
+	}
+	return "", fmt.Errorf("unknown locale %q", locale)
+}
+
+func (testLocaleParser) Time(locale string, value string) (time.Time, error) {
+	if locale != "de-DE" {
+		return time.Time{}, fmt.Errorf("unknown locale %q", locale)
+	}
+	return time.Parse("02.01.2006", value)
+}
+
+func TestBindQueryParams_Locale(t *testing.T) {
+	type query struct {
+		Price  float64    `query:"price" locale:"de-DE"`
+		Prices []float64  `query:"prices" locale:"de-DE"`
+		Count  int        `query:"count"`
+		Date   time.Time  `query:"date" locale:"de-DE"`
+		Since  *time.Time `query:"since" locale:"de-DE"`
+		Name   string     `query:"name" locale:"de-DE"`
+	}
+	b := &DefaultBinder{LocaleParser: testLocaleParser{}, Locale: "en-US"}
+	req := newRequest(http.MethodGet, "/?price=1.234,5&prices=1,5&prices=2.000&count=1,000&date=24.12.2024&since=01.02.2024&name=a.b,c", "", "")
+	var dest query
+	if err := b.BindQueryParams(req, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	since := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	want := query{
+		Price:  1234.5,
+		Prices: []float64{1.5, 2000},
+		Count:  1000,
+		Date:   time.Date(2024, 12, 24, 0, 0, 0, 0, time.UTC),
+		Since:  &since,
+		Name:   "a.b,c",
+	}
+	if !reflect.DeepEqual(dest, want) {
+		t.Errorf("got %+v, want %+v", dest, want)
+	}
+
+	var invalid query
+	if err := b.BindQueryParams(newRequest(http.MethodGet, "/?date=2024-12-24", "", ""), &invalid); err == nil {
+		t.Error("expected error of locale parser")
+	}
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?price=1.234,5", "", ""), &invalid); err == nil {
+		t.Error("expected standard parsing without locale parser")
+	}
+}
+
+func TestBindQueryParams_NumberSeparators(t *testing.T) {
+	type query struct {
+		Price  float64   `query:"price"`
+		Qty    int       `query:"qty"`
+		Prices []float64 `query:"prices"`
+		Ptr    *uint     `query:"ptr"`
+		Local  float64   `query:"local" locale:"en-US"`
+		Name   string    `query:"name"`
+	}
+	b := NewBinder(WithNumberSeparators(".", ","))
+	b.LocaleParser = testLocaleParser{}
+	req := newRequest(http.MethodGet, "/?price=1.234,5&qty=1.000&prices=0,5&prices=2&ptr=12.345&local=1,234.5&name=a.b,c", "", "")
+	var dest query
+	if err := b.BindQueryParams(req, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ptr := uint(12345)
+	want := query{Price: 1234.5, Qty: 1000, Prices: []float64{0.5, 2}, Ptr: &ptr, Local: 1234.5, Name: "a.b,c"}
+	if !reflect.DeepEqual(dest, want) {
+		t.Errorf("got %+v, want %+v", dest, want)
+	}
+
+	if err := b.BindQueryParams(newRequest(http.MethodGet, "/?price=1,234,5", "", ""), &query{}); err == nil {
+		t.Error("other notation: expected error")
+	}
+	var plain query
+	if err := NewBinder().BindQueryParams(newRequest(http.MethodGet, "/?price=1234.5", "", ""), &plain); err != nil || plain.Price != 1234.5 {
+		t.Errorf("without separators: got %+v, error %v", plain, err)
+	}
+}
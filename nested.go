@@ -0,0 +1,175 @@
+package binding
+
+import (
+	"encoding"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	contextBindUnmarshalerType  = reflect.TypeOf((*ContextBindUnmarshaler)(nil)).Elem()
+	textUnmarshalerType         = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	binaryUnmarshalerType       = reflect.TypeOf((*encoding.BinaryUnmarshaler)(nil)).Elem()
+	bindMultipleUnmarshalerType = reflect.TypeOf((*bindMultipleUnmarshaler)(nil)).Elem()
+)
+
+// isNamespaced reports whether tagged field binds from keys prefixed with its name, that is ordinary struct or map
+// with string keys (or pointer to them) without format which does not convert input values itself. Embedded structs
+// qualify as well, untagged ones are flattened before.
+func isNamespaced(field reflect.StructField) bool {
+	typ := field.Type
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == timeType || field.Tag.Get("format") != "" {
+		return false
+	}
+	if typ.Kind() != reflect.Struct && (typ.Kind() != reflect.Map || typ.Key().Kind() != reflect.String) {
+		return false
+	}
+	if isSetType(typ) && typ.Elem().Kind() != reflect.Bool {
+		// map[K]struct{} is a set, map[K]bool binds both ways
+		return false
+	}
+	ptr := reflect.PointerTo(typ)
+	return !ptr.Implements(bindUnmarshalerType) && !ptr.Implements(contextBindUnmarshalerType) &&
+		!ptr.Implements(textUnmarshalerType) && !ptr.Implements(binaryUnmarshalerType) &&
+		!ptr.Implements(bindMultipleUnmarshalerType)
+}
+
+// isConvertibleType reports whether input values convert into values of type typ (or pointers, slices and arrays of
+// it) with the regular conversion rules
+func isConvertibleType(typ reflect.Type) bool {
+	for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array {
+		typ = typ.Elem()
+	}
+	if _, ok := lookupConverter(typ); ok || typ == timeType || typ == durationType {
+		return true
+	}
+	ptr := reflect.PointerTo(typ)
+	if ptr.Implements(bindUnmarshalerType) || ptr.Implements(contextBindUnmarshalerType) ||
+		ptr.Implements(textUnmarshalerType) || ptr.Implements(binaryUnmarshalerType) {
+		return true
+	}
+	switch typ.Kind() {
+	case reflect.String, reflect.Bool, reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	}
+	return isNumberKind(typ.Kind())
+}
+
+// isSetType reports whether typ is a set of values, map with empty struct or bool elements whose keys convert from
+// input values
+func isSetType(typ reflect.Type) bool {
+	if typ.Kind() != reflect.Map || !isConvertibleType(typ.Key()) {
+		return false
+	}
+	key := typ.Key().Kind()
+	if key == reflect.Ptr || key == reflect.Slice || key == reflect.Array || key == reflect.Interface {
+		return false
+	}
+	elem := typ.Elem()
+	return elem.Kind() == reflect.Bool || elem.Kind() == reflect.Struct && elem.NumField() == 0
+}
+
+// hasKey reports whether data has values for name
+func hasKey(data map[string][]string, name string, caseSensitive bool) bool {
+	if _, ok := data[name]; ok || caseSensitive {
+		return ok
+	}
+	for key := range data {
+		if strings.EqualFold(key, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// namespaceData returns data of keys in namespace prefix i.e. `address.city` or `address[city]` for prefix `address`
+// with the prefix stripped (`city`). Deeper levels keep their notation so `address[geo][lat]` becomes `geo[lat]`.
+func namespaceData(data map[string][]string, prefix string, caseSensitive bool) map[string][]string {
+	sub := map[string][]string{}
+	for k, v := range data {
+		if len(k) <= len(prefix)+1 {
+			continue
+		}
+		if head := k[:len(prefix)]; head != prefix && (caseSensitive || !strings.EqualFold(head, prefix)) {
+			continue
+		}
+		rest := k[len(prefix):]
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end <= 1 {
+				continue
+			}
+			rest = rest[1:end] + rest[end+1:]
+		default:
+			continue
+		}
+		sub[rest] = append(sub[rest], v...)
+	}
+	return sub
+}
+
+// indexedValues returns values of keys `name[<index>]` i.e. `items[0]=a&items[1]=b` ordered by index (gaps are
+// dropped) and reports whether there were any. Keys `name[]` count as index 0 so they keep order of appearance.
+func indexedValues(data map[string][]string, name string, caseSensitive bool) ([]string, bool) {
+	type indexed struct {
+		index  int
+		values []string
+	}
+	var found []indexed
+	for k, v := range data {
+		if len(k) < len(name)+2 || k[len(k)-1] != ']' || k[len(name)] != '[' {
+			continue
+		}
+		if head := k[:len(name)]; head != name && (caseSensitive || !strings.EqualFold(head, name)) {
+			continue
+		}
+		index := 0
+		if digits := k[len(name)+1 : len(k)-1]; digits != "" {
+			n, err := strconv.Atoi(digits)
+			if err != nil || n < 0 {
+				continue
+			}
+			index = n
+		}
+		found = append(found, indexed{index: index, values: v})
+	}
+	if len(found) == 0 {
+		return nil, false
+	}
+	sort.SliceStable(found, func(i, j int) bool { return found[i].index < found[j].index })
+	var values []string
+	for _, f := range found {
+		values = append(values, f.values...)
+	}
+	return values, true
+}
+
+// bindMapEntries sets entries of map field from namespaced data, values are converted with the regular conversion
+// rules of the map element type
+func bindMapEntries(s *bindState, path, tag, name string, typeField reflect.StructField, field reflect.Value, data map[string][]string) error {
+	typ := field.Type()
+	if field.IsNil() {
+		field.Set(reflect.MakeMapWithSize(typ, len(data)))
+	}
+	for k, values := range data {
+		elem := reflect.New(typ.Elem()).Elem()
+		key := name + "[" + k + "]"
+		in := fieldInput{tag: tag, path: fieldPath(path, typeField), name: key, field: typeField, values: values}
+		if err := setField(s, in, elem); err != nil {
+			if err = s.fieldError(tag, key, in.path, values, err); err != nil {
+				return err
+			}
+			continue
+		}
+		field.SetMapIndex(reflect.ValueOf(k).Convert(typ.Key()), elem)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package binding
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+type EmbeddedPagination struct {
+	Page int `query:"page"`
+	Size int `query:"size"`
+}
+
+func TestBindQueryParams_EmbeddedStructs(t *testing.T) {
+	type tagged struct {
+		EmbeddedPagination `query:"p"`
+	}
+	type untagged struct {
+		EmbeddedPagination
+	}
+	var tq tagged
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?p.page=2&p[size]=10&page=9", "", ""), &tq); err != nil {
+		t.Fatal(err)
+	}
+	if tq.Page != 2 || tq.Size != 10 {
+		t.Fatalf("tagged embedded struct: %+v", tq.EmbeddedPagination)
+	}
+	var uq untagged
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?page=3&size=5&p.page=9", "", ""), &uq); err != nil {
+		t.Fatal(err)
+	}
+	if uq.Page != 3 || uq.Size != 5 {
+		t.Fatalf("untagged embedded struct: %+v", uq.EmbeddedPagination)
+	}
+}
+
+func TestBindQueryParams_EmbeddedPointers(t *testing.T) {
+	type tagged struct {
+		*EmbeddedPagination `query:"p"`
+	}
+	type untagged struct {
+		*EmbeddedPagination
+	}
+
+	var tq tagged
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?p.page=2", "", ""), &tq); err != nil {
+		t.Fatal(err)
+	}
+	if tq.EmbeddedPagination == nil || tq.Page != 2 {
+		t.Fatalf("tagged embedded pointer not bound: %+v", tq.EmbeddedPagination)
+	}
+	tq = tagged{}
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?page=2", "", ""), &tq); err != nil {
+		t.Fatal(err)
+	}
+	if tq.EmbeddedPagination != nil {
+		t.Fatalf("tagged embedded pointer allocated without its keys: %+v", tq.EmbeddedPagination)
+	}
+
+	var uq untagged
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?page=3", "", ""), &uq); err != nil {
+		t.Fatal(err)
+	}
+	if uq.EmbeddedPagination == nil || uq.Page != 3 {
+		t.Fatalf("untagged embedded pointer not bound: %+v", uq.EmbeddedPagination)
+	}
+	uq = untagged{}
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?x=1", "", ""), &uq); err != nil {
+		t.Fatal(err)
+	}
+	if uq.EmbeddedPagination != nil {
+		t.Fatalf("untagged embedded pointer allocated without its keys: %+v", uq.EmbeddedPagination)
+	}
+
+	existing := &EmbeddedPagination{Page: 1}
+	uq = untagged{EmbeddedPagination: existing}
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?page=4", "", ""), &uq); err != nil {
+		t.Fatal(err)
+	}
+	if uq.EmbeddedPagination != existing || existing.Page != 4 {
+		t.Fatalf("expected existing pointer to be bound into, got %+v", uq.EmbeddedPagination)
+	}
+}
+
+type nestedGeo struct {
+	Lat float64 `query:"lat"`
+	Lng float64 `query:"lng"`
+}
+
+type nestedAddress struct {
+	City string     `query:"city"`
+	Zip  string     `query:"zip"`
+	Geo  nestedGeo  `query:"geo"`
+	Pin  *nestedGeo `query:"pin"`
+}
+
+func TestBindQueryParams_TwoLevelNesting(t *testing.T) {
+	type dest struct {
+		Name    string         `query:"name"`
+		Address nestedAddress  `query:"address"`
+		Billing *nestedAddress `query:"billing"`
+	}
+	testCases := []struct {
+		name  string
+		query string
+	}{
+		{name: "dots", query: "name=n&address.city=NYC&address.zip=10001&address.geo.lat=40.7&address.geo.lng=-74&billing.geo.lat=1"},
+		{name: "brackets", query: "name=n&address[city]=NYC&address[zip]=10001&address[geo][lat]=40.7&address[geo][lng]=-74&" +
+			"billing[geo][lat]=1"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var d dest
+			if err := BindQueryParams(newRequest(http.MethodGet, "/?"+tc.query, "", ""), &d); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			want := nestedAddress{City: "NYC", Zip: "10001", Geo: nestedGeo{Lat: 40.7, Lng: -74}}
+			if d.Name != "n" || d.Address != want {
+				t.Errorf("got %+v", d)
+			}
+			if d.Address.Pin != nil {
+				t.Errorf("pointer without keys allocated: %+v", d.Address.Pin)
+			}
+			if d.Billing == nil || d.Billing.Geo.Lat != 1 || d.Billing.City != "" {
+				t.Errorf("Billing = %+v", d.Billing)
+			}
+		})
+	}
+}
+
+func TestBindQueryParams_NestingKeepsKeysApart(t *testing.T) {
+	type dest struct {
+		City    string        `query:"city"`
+		Address nestedAddress `query:"address"`
+	}
+	var d dest
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?city=top&address.city=nested&lat=1&geo.lat=2", "", ""), &d); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.City != "top" || d.Address.City != "nested" || d.Address.Geo.Lat != 0 {
+		t.Errorf("got %+v", d)
+	}
+}
+
+func TestBindQueryParams_Sets(t *testing.T) {
+	type query struct {
+		Tags  map[string]struct{} `query:"tags"`
+		IDs   map[int64]bool      `query:"ids"`
+		Flags map[string]bool     `query:"flags"`
+	}
+	var q query
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?tags=a&tags=b&tags=a&tags=&ids=1&ids=2&flags[beta]=true&flags[old]=false", "", ""), &q); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := map[string]struct{}{"a": {}, "b": {}}; !reflect.DeepEqual(q.Tags, want) {
+		t.Errorf("Tags = %v, want %v", q.Tags, want)
+	}
+	if want := map[int64]bool{1: true, 2: true}; !reflect.DeepEqual(q.IDs, want) {
+		t.Errorf("IDs = %v, want %v", q.IDs, want)
+	}
+	if want := map[string]bool{"beta": true, "old": false}; !reflect.DeepEqual(q.Flags, want) {
+		t.Errorf("Flags = %v, want %v", q.Flags, want)
+	}
+
+	var set query
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?flags=beta&flags=new", "", ""), &set); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := map[string]bool{"beta": true, "new": true}; !reflect.DeepEqual(set.Flags, want) {
+		t.Errorf("Flags = %v, want %v", set.Flags, want)
+	}
+
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?ids=1&ids=x", "", ""), &query{}); err == nil {
+		t.Error("invalid member: expected error")
+	}
+	b := NewBinder(WithMultiValueScalarPolicy(MultiValueError))
+	if err := b.BindQueryParams(newRequest(http.MethodGet, "/?tags=a&tags=b", "", ""), &query{}); err != nil {
+		t.Errorf("sets are not single value fields: unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package binding
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+var (
+	normalizersMu sync.RWMutex
+	// normalizers are string normalizers selected by `normalize` struct tag
+	normalizers = map[string]func(string) string{
+		"collapse-space": collapseSpace,
+	}
+)
+
+// RegisterNormalizer registers normalizer function for fields tagged with `normalize:"<name>"`. Normalizer is applied to
+// every input value of the field before conversion. This package does not import unicode normalization to avoid the
+// dependency, register it from golang.org/x/text/unicode/norm instead:
+//
+//	binding.RegisterNormalizer("nfc", norm.NFC.String)
+//	binding.RegisterNormalizer("nfkc", norm.NFKC.String)
+func RegisterNormalizer(name string, fn func(string) string) {
+	normalizersMu.Lock()
+	defer normalizersMu.Unlock()
+	normalizers[name] = fn
+}
+
+// normalizeValues returns copy of values normalized with normalizers registered under comma separated names, applied
+// in order. Binding fails when normalizer is not registered as silently skipping (security relevant) normalization
+// would go unnoticed.
+func normalizeValues(names string, values []string) ([]string, error) {
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		normalizersMu.RLock()
+		fn, ok := normalizers[name]
+		normalizersMu.RUnlock()
+		if !ok {
+			return nil, fmt.Errorf("normalizer %q is not registered", name)
+		}
+		values = mapValues(values, fn)
+	}
+	return values, nil
+}
+
+// collapseSpace replaces runs of white space with single space and trims the value, i.e. for free-text search
+func collapseSpace(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
@@ -0,0 +1,69 @@
+package binding
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBindQueryParams_Normalize(t *testing.T) {
+	// composes `e` followed by combining acute accent like NFC does
+	RegisterNormalizer("test-compose", func(s string) string { return strings.ReplaceAll(s, "e\u0301", "\u00e9") })
+	type user struct {
+		Username string   `query:"username" normalize:"test-compose"`
+		Aliases  []string `query:"alias" normalize:"test-compose"`
+		Raw      string   `query:"raw"`
+	}
+	req := newRequest(http.MethodGet, "/?username=jose%CC%81&alias=rene%CC%81&alias=bob&raw=jose%CC%81", "", "")
+	var dest user
+	if err := BindQueryParams(req, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dest.Username != "jos\u00e9" {
+		t.Errorf("Username = %q, want normalized", dest.Username)
+	}
+	if want := []string{"ren\u00e9", "bob"}; !reflect.DeepEqual(dest.Aliases, want) {
+		t.Errorf("Aliases = %q, want %q", dest.Aliases, want)
+	}
+	if dest.Raw != "jose\u0301" {
+		t.Errorf("Raw = %q, want untouched value", dest.Raw)
+	}
+}
+
+func TestBindQueryParams_NormalizerNotRegistered(t *testing.T) {
+	type user struct {
+		Username string `query:"username" normalize:"test-missing"`
+	}
+	var dest user
+	err := BindQueryParams(newRequest(http.MethodGet, "/?username=bob", "", ""), &dest)
+	if err == nil || !strings.Contains(err.Error(), `"test-missing"`) {
+		t.Fatalf("got error %v, want unregistered normalizer error", err)
+	}
+	if dest.Username != "" {
+		t.Errorf("Username = %q, want unbound", dest.Username)
+	}
+}
+
+func TestBindQueryParams_CollapseSpace(t *testing.T) {
+	RegisterNormalizer("test-lower", strings.ToLower)
+	type search struct {
+		Query string `query:"q" normalize:"collapse-space"`
+		Tag   string `query:"tag" normalize:"test-lower, collapse-space"`
+	}
+	req := newRequest(http.MethodGet, "/?q=%20red%20%20%0A%09shoes%20&tag=%20Big%20%20SALE", "", "")
+	var dest search
+	if err := BindQueryParams(req, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dest.Query != "red shoes" || dest.Tag != "big sale" {
+		t.Errorf("got %+v", dest)
+	}
+
+	var missing struct {
+		Query string `query:"q" normalize:"collapse-space,test-missing"`
+	}
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?q=a", "", ""), &missing); err == nil {
+		t.Error("unregistered normalizer in chain: expected error")
+	}
+}
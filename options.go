@@ -0,0 +1,292 @@
+package binding
+
+import (
+	"reflect"
+	"time"
+)
+
+// Source is a request data source taking part in Bind
+type Source string
+
+const (
+	// SourcePath binds path params (`param` tag)
+	SourcePath Source = "param"
+	// SourceQuery binds query params (`query` tag)
+	SourceQuery Source = "query"
+	// SourceHeader binds headers (`header` tag)
+	SourceHeader Source = "header"
+	// SourceCookie binds cookies (`cookie` tag)
+	SourceCookie Source = "cookie"
+	// SourceBody binds request body (`json`, `xml` and `form` tags)
+	SourceBody Source = "body"
+)
+
+// Option configures DefaultBinder created by NewBinder
+type Option func(b *DefaultBinder)
+
+// NewBinder creates DefaultBinder configured with given options. Options only set DefaultBinder fields, so binder can
+// be further configured through them.
+func NewBinder(opts ...Option) *DefaultBinder {
+	b := &DefaultBinder{}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// WithCaseSensitive makes param, query, form, header and cookie keys to be matched only exactly, see
+// DefaultBinder.CaseSensitive.
+func WithCaseSensitive(caseSensitive bool) Option {
+	return func(b *DefaultBinder) {
+		b.CaseSensitive = caseSensitive
+	}
+}
+
+// WithSources selects sources Bind binds from, see DefaultBinder.Sources
+func WithSources(sources ...Source) Option {
+	return func(b *DefaultBinder) {
+		b.Sources = sources
+	}
+}
+
+// WithStrictJSON makes JSON bodies with fields unknown to the destination or with trailing data fail to bind, see
+// DefaultBinder.StrictJSON
+func WithStrictJSON(strict bool) Option {
+	return func(b *DefaultBinder) {
+		b.StrictJSON = strict
+	}
+}
+
+// WithJSONUseNumber decodes JSON numbers into interface{} values as json.Number, see DefaultBinder.JSONUseNumber
+func WithJSONUseNumber(useNumber bool) Option {
+	return func(b *DefaultBinder) {
+		b.JSONUseNumber = useNumber
+	}
+}
+
+// WithMaxBodySize limits size of request body BindBody reads, see DefaultBinder.MaxBodySize
+func WithMaxBodySize(n int64) Option {
+	return func(b *DefaultBinder) {
+		b.MaxBodySize = n
+	}
+}
+
+// WithTimeout limits total time of a bind call, see DefaultBinder.Timeout
+func WithTimeout(d time.Duration) Option {
+	return func(b *DefaultBinder) {
+		b.Timeout = d
+	}
+}
+
+// WithSchemaCompat makes query and form binding compatible with gorilla/schema `schema` tags, see
+// DefaultBinder.SchemaCompat.
+func WithSchemaCompat(schemaCompat bool) Option {
+	return func(b *DefaultBinder) {
+		b.SchemaCompat = schemaCompat
+	}
+}
+
+// WithSourceTag makes input names of source to be read from struct tag name, see DefaultBinder.SourceTags
+func WithSourceTag(source Source, name string) Option {
+	return func(b *DefaultBinder) {
+		if b.SourceTags == nil {
+			b.SourceTags = map[Source]string{}
+		}
+		b.SourceTags[source] = name
+	}
+}
+
+// WithQueryTag makes query param names to be read from struct tag name, i.e. `json`
+func WithQueryTag(name string) Option {
+	return WithSourceTag(SourceQuery, name)
+}
+
+// WithFormTag makes form field names to be read from struct tag name, i.e. `json`
+func WithFormTag(name string) Option {
+	return WithSourceTag(SourceBody, name)
+}
+
+// WithTrimSpace trims white space of all input values, see DefaultBinder.TrimSpace
+func WithTrimSpace(trim bool) Option {
+	return func(b *DefaultBinder) {
+		b.TrimSpace = trim
+	}
+}
+
+// WithRejectConflicts fails binds setting a field to different values from multiple sources, see
+// DefaultBinder.RejectConflicts
+func WithRejectConflicts(reject bool) Option {
+	return func(b *DefaultBinder) {
+		b.RejectConflicts = reject
+	}
+}
+
+// WithReusableBody keeps request body readable after binding it, see DefaultBinder.ReusableBody
+func WithReusableBody(reusable bool) Option {
+	return func(b *DefaultBinder) {
+		b.ReusableBody = reusable
+	}
+}
+
+// WithErrorTranslator replaces conversion errors with errors returned by fn, see DefaultBinder.ErrorTranslator
+func WithErrorTranslator(fn func(field, source, value string, kind reflect.Kind, err error) error) Option {
+	return func(b *DefaultBinder) {
+		b.ErrorTranslator = fn
+	}
+}
+
+// WithMultiValueScalarPolicy selects value of single value fields receiving multiple values, see
+// DefaultBinder.MultiValueScalarPolicy
+func WithMultiValueScalarPolicy(policy MultiValueScalarPolicy) Option {
+	return func(b *DefaultBinder) {
+		b.MultiValueScalarPolicy = policy
+	}
+}
+
+// WithAutoNumberBase parses integers like Go integer literals, see DefaultBinder.AutoNumberBase
+func WithAutoNumberBase(auto bool) Option {
+	return func(b *DefaultBinder) {
+		b.AutoNumberBase = auto
+	}
+}
+
+// WithCanonicalJSON rejects JSON bodies that are not canonical, see DefaultBinder.CanonicalJSON
+func WithCanonicalJSON(canonical bool) Option {
+	return func(b *DefaultBinder) {
+		b.CanonicalJSON = canonical
+	}
+}
+
+// WithEmptyBoolPolicy sets how present but empty values bind into bool fields of source, see
+// DefaultBinder.EmptyBoolPolicies
+func WithEmptyBoolPolicy(source Source, policy EmptyBoolPolicy) Option {
+	return func(b *DefaultBinder) {
+		if b.EmptyBoolPolicies == nil {
+			b.EmptyBoolPolicies = map[Source]EmptyBoolPolicy{}
+		}
+		b.EmptyBoolPolicies[source] = policy
+	}
+}
+
+// WithErrorMode sets whether field errors of source fail fast or are collected, see DefaultBinder.ErrorModes
+func WithErrorMode(source Source, mode ErrorMode) Option {
+	return func(b *DefaultBinder) {
+		if b.ErrorModes == nil {
+			b.ErrorModes = map[Source]ErrorMode{}
+		}
+		b.ErrorModes[source] = mode
+	}
+}
+
+// WithEventTypeKey names discriminator member of events bound by BindEvents, see DefaultBinder.EventTypeKey
+func WithEventTypeKey(key string) Option {
+	return func(b *DefaultBinder) {
+		b.EventTypeKey = key
+	}
+}
+
+// WithSkipUnknownEvents drops events of unregistered types in BindEvents, see DefaultBinder.SkipUnknownEvents
+func WithSkipUnknownEvents(skip bool) Option {
+	return func(b *DefaultBinder) {
+		b.SkipUnknownEvents = skip
+	}
+}
+
+// WithCSVRejectUnknownColumns rejects CSV columns not mapped to any field, see DefaultBinder.CSVRejectUnknownColumns
+func WithCSVRejectUnknownColumns(reject bool) Option {
+	return func(b *DefaultBinder) {
+		b.CSVRejectUnknownColumns = reject
+	}
+}
+
+// WithEmptySliceClears binds empty values into slice fields as empty slices, see DefaultBinder.EmptySliceClears
+func WithEmptySliceClears(clears bool) Option {
+	return func(b *DefaultBinder) {
+		b.EmptySliceClears = clears
+	}
+}
+
+// WithFieldErrorInputKeys names fields of FieldError by input keys, see DefaultBinder.FieldErrorInputKeys
+func WithFieldErrorInputKeys(inputKeys bool) Option {
+	return func(b *DefaultBinder) {
+		b.FieldErrorInputKeys = inputKeys
+	}
+}
+
+// WithMaxJSONDepth limits nesting depth of JSON bodies, see DefaultBinder.MaxJSONDepth
+func WithMaxJSONDepth(depth int) Option {
+	return func(b *DefaultBinder) {
+		b.MaxJSONDepth = depth
+	}
+}
+
+// WithUnmarshalCache caches values of custom unmarshalers in cache, see DefaultBinder.UnmarshalCache
+func WithUnmarshalCache(cache UnmarshalCache) Option {
+	return func(b *DefaultBinder) {
+		b.UnmarshalCache = cache
+	}
+}
+
+// WithTenantBaseDomain takes tenant of `format:"tenant"` fields from subdomains of domain, see
+// DefaultBinder.TenantBaseDomain
+func WithTenantBaseDomain(domain string) Option {
+	return func(b *DefaultBinder) {
+		b.TenantBaseDomain = domain
+	}
+}
+
+// WithNumberSeparators sets separators of numeric field values, i.e. `.` and `,` for `1.234,5`, see
+// DefaultBinder.ThousandsSeparator and DefaultBinder.DecimalSeparator
+func WithNumberSeparators(thousands, decimal string) Option {
+	return func(b *DefaultBinder) {
+		b.ThousandsSeparator = thousands
+		b.DecimalSeparator = decimal
+	}
+}
+
+// WithJSONRoot binds JSON bodies from the object at dot separated root path, see DefaultBinder.JSONRoot
+func WithJSONRoot(root string) Option {
+	return func(b *DefaultBinder) {
+		b.JSONRoot = root
+	}
+}
+
+// WithStringInterner deduplicates values bound into string fields with fn, see DefaultBinder.StringInterner
+func WithStringInterner(fn func(string) string) Option {
+	return func(b *DefaultBinder) {
+		b.StringInterner = fn
+	}
+}
+
+// WithValidator validates bound objects with fn, i.e. go-playground/validator `validate.Struct`, see
+// DefaultBinder.Validator
+func WithValidator(fn func(i interface{}) error) Option {
+	return func(b *DefaultBinder) {
+		b.Validator = fn
+	}
+}
+
+// nameTag returns struct tag input names of source tag are read from
+func (b *DefaultBinder) nameTag(tag string) string {
+	source := Source(tag)
+	if tag == "form" {
+		source = SourceBody
+	}
+	if name := b.SourceTags[source]; name != "" {
+		return name
+	}
+	return tag
+}
+
+// includes reports whether Bind binds from the source
+func (b *DefaultBinder) includes(source Source) bool {
+	if b.Sources == nil {
+		return source != SourceHeader && (source != SourceCookie || b.IncludeCookies)
+	}
+	for _, s := range b.Sources {
+		if s == source {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,229 @@
+package binding
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type jsonTagged struct {
+	Name   string   `json:"name,omitempty"`
+	Page   int      `json:"page,string"`
+	Tags   []string `json:"tags"`
+	Secret string   `json:"-"`
+	Query  string   `json:"q" query:"other"`
+}
+
+func TestWithQueryTag(t *testing.T) {
+	b := NewBinder(WithQueryTag("json"))
+	var d jsonTagged
+	query := "/?name=n&page=2&tags=a&tags=b&Secret=s&-=s&q=json&other=query"
+	if err := b.BindQueryParams(newRequest(http.MethodGet, query, "", ""), &d); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.Name != "n" || d.Page != 2 || len(d.Tags) != 2 || d.Query != "json" {
+		t.Errorf("got %+v", d)
+	}
+	if d.Secret != "" {
+		t.Errorf("field tagged json:\"-\" bound: %q", d.Secret)
+	}
+}
+
+func TestWithFormTag(t *testing.T) {
+	b := NewBinder(WithFormTag("json"))
+	var d jsonTagged
+	req := newRequest(http.MethodPost, "/?name=query", MIMEApplicationForm, "name=n&page=3&tags=a")
+	if err := b.BindBody(req, &d); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.Name != "n" || d.Page != 3 || len(d.Tags) != 1 {
+		t.Errorf("got %+v", d)
+	}
+}
+
+func TestWithSourceTag_OtherSourcesKeepTheirTags(t *testing.T) {
+	b := NewBinder(WithSourceTag(SourceQuery, "json"))
+	var d struct {
+		Name  string `json:"name" header:"X-Name"`
+		Token string `header:"X-Token"`
+	}
+	req := newRequest(http.MethodGet, "/?name=q", "", "")
+	req.Header.Set("X-Name", "h")
+	req.Header.Set("X-Token", "t")
+	if err := b.BindHeaders(req, &d); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.Name != "h" || d.Token != "t" {
+		t.Errorf("got %+v", d)
+	}
+	if err := b.BindQueryParams(req, &d); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.Name != "q" {
+		t.Errorf("Name = %q, want q", d.Name)
+	}
+}
+
+var errInvalidAge = errors.New("age must be positive")
+
+type validatedUser struct {
+	Name string `query:"name" json:"name"`
+	Age  int    `query:"age" json:"age"`
+}
+
+// validateUser is a stub validator rejecting users without positive age
+func validateUser(calls *int) func(i interface{}) error {
+	return func(i interface{}) error {
+		*calls++
+		if u, ok := i.(*validatedUser); ok && u.Age <= 0 {
+			return errInvalidAge
+		}
+		return nil
+	}
+}
+
+func TestWithValidator(t *testing.T) {
+	var calls int
+	b := NewBinder(WithValidator(validateUser(&calls)))
+	binds := map[string]func(r *http.Request, i interface{}) error{
+		"Bind":            func(r *http.Request, i interface{}) error { return b.Bind(i, r) },
+		"BindQueryParams": b.BindQueryParams,
+		"BindWith": func(r *http.Request, i interface{}) error {
+			return b.BindWith(i, r, SourceQuery)
+		},
+	}
+	for name, bind := range binds {
+		t.Run(name, func(t *testing.T) {
+			calls = 0
+			var u validatedUser
+			if err := bind(newRequest(http.MethodGet, "/?name=n&age=0", "", ""), &u); !errors.Is(err, errInvalidAge) {
+				t.Errorf("error = %v, want validator error", err)
+			}
+			if err := bind(newRequest(http.MethodGet, "/?name=n&age=3", "", ""), &u); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if calls != 2 {
+				t.Errorf("validator called %d times, want 2", calls)
+			}
+		})
+	}
+
+	calls = 0
+	var u validatedUser
+	if err := b.BindBody(newRequest(http.MethodPost, "/", MIMEApplicationJSON, `{"name":"n","age":-1}`), &u); !errors.Is(err, errInvalidAge) {
+		t.Errorf("BindBody error = %v, want validator error", err)
+	}
+	if calls != 1 {
+		t.Errorf("validator called %d times, want 1", calls)
+	}
+}
+
+func TestWithValidator_SkippedOnBindError(t *testing.T) {
+	var calls int
+	b := NewBinder(WithValidator(validateUser(&calls)))
+	var u validatedUser
+	err := b.BindQueryParams(newRequest(http.MethodGet, "/?age=x", "", ""), &u)
+	if err == nil || errors.Is(err, errInvalidAge) {
+		t.Fatalf("error = %v, want bind error", err)
+	}
+	if err := b.BindBody(newRequest(http.MethodPost, "/", MIMEApplicationJSON, `{"age":`), &u); err == nil {
+		t.Fatal("expected bind error")
+	}
+	if calls != 0 {
+		t.Errorf("validator called %d times after failed binds", calls)
+	}
+}
+
+func TestWithErrorTranslator(t *testing.T) {
+	type call struct {
+		field, source, value string
+		kind                 reflect.Kind
+		raw                  error
+	}
+	var calls []call
+	b := NewBinder(WithErrorTranslator(func(field, source, value string, kind reflect.Kind, err error) error {
+		calls = append(calls, call{field, source, value, kind, err})
+		if kind == reflect.Int {
+			return fmt.Errorf("%s must be a whole number", field)
+		}
+		return err
+	}))
+	var dest struct {
+		Age  int  `query:"age"`
+		Flag bool `query:"flag"`
+	}
+	err := b.BindQueryParams(newRequest(http.MethodGet, "/?age=abc", "", ""), &dest)
+	if err == nil || err.Error() != "age must be a whole number" {
+		t.Fatalf("error = %v, want translated error", err)
+	}
+	if strings.Contains(err.Error(), "strconv") {
+		t.Errorf("raw strconv error leaked: %v", err)
+	}
+	var fe FieldError
+	if !errors.As(err, &fe) || fe.Field() != "Age" || fe.Source() != "query" || fe.Value() != "abc" {
+		t.Errorf("expected FieldError of Age, got %v", err)
+	}
+	if len(calls) != 1 || calls[0].field != "age" || calls[0].source != "query" || calls[0].value != "abc" ||
+		calls[0].kind != reflect.Int {
+		t.Fatalf("translator called with %+v", calls)
+	}
+	var numErr *strconv.NumError
+	if !errors.As(calls[0].raw, &numErr) {
+		t.Errorf("translator got raw error %v, want *strconv.NumError", calls[0].raw)
+	}
+
+	calls = nil
+	if err := b.BindQueryParams(newRequest(http.MethodGet, "/?age=7&flag=1", "", ""), &dest); err != nil || len(calls) != 0 {
+		t.Errorf("error = %v, translator calls = %d, want translator not called for valid values", err, len(calls))
+	}
+}
+
+func TestNewBinder(t *testing.T) {
+	b := NewBinder(
+		WithCaseSensitive(true),
+		WithSources(SourceQuery, SourceBody),
+		WithStrictJSON(true),
+		WithMaxBodySize(1<<10),
+		WithTimeout(time.Second),
+	)
+	if !b.CaseSensitive || !reflect.DeepEqual(b.Sources, []Source{SourceQuery, SourceBody}) || !b.StrictJSON ||
+		b.MaxBodySize != 1<<10 || b.Timeout != time.Second {
+		t.Errorf("options not applied: %+v", b)
+	}
+	if d := NewBinder(); !reflect.DeepEqual(d, &DefaultBinder{}) {
+		t.Errorf("NewBinder() = %+v, want zero DefaultBinder", d)
+	}
+}
+
+func TestWithSources(t *testing.T) {
+	type dest struct {
+		Page  int    `query:"page"`
+		Agent string `header:"X-Agent"`
+		Name  string `json:"name"`
+	}
+	cases := []struct {
+		name    string
+		sources []Source
+		want    dest
+	}{
+		{"default", nil, dest{Page: 2, Name: "n"}},
+		{"query only", []Source{SourceQuery}, dest{Page: 2}},
+		{"header and body", []Source{SourceHeader, SourceBody}, dest{Agent: "a", Name: "n"}},
+	}
+	for _, c := range cases {
+		req := newRequest(http.MethodPost, "/?page=2", "application/json", `{"name":"n"}`)
+		req.Header.Set("X-Agent", "a")
+		var d dest
+		if err := NewBinder(WithSources(c.sources...)).Bind(&d, req); err != nil {
+			t.Fatalf("%s: unexpected error: %v", c.name, err)
+		}
+		if d != c.want {
+			t.Errorf("%s: got %+v, want %+v", c.name, d, c.want)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package binding
+
+import (
+	"context"
+	"reflect"
+)
+
+type sourceOverridesKey struct{}
+
+// overrideTags are source tags a field bound from overridden source takes its name from when it has no tag of that
+// source, in order of preference
+var overrideTags = []string{"param", "query", "header", "cookie", "form"}
+
+// WithSourceOverrides returns copy of ctx that makes fields of the request bound with it to bind from given source
+// instead of the source declared by their tags, i.e. middleware forcing `Version` from a header for canary requests:
+//
+//	ctx := binding.WithSourceOverrides(r.Context(), map[string]binding.Source{"Version": binding.SourceHeader})
+//	r = r.WithContext(ctx)
+//
+// Keys are field paths, Go field names of nested structs joined by `.` i.e. `Filter.Version`. Override beats the
+// declared source, the field is skipped by all other sources and bound by the overriding one using its tag for that
+// source or else the name of its first param, query, header, cookie or form tag. Bind binds overridden fields even from
+// sources it does not include otherwise (headers by default). SourceBody overrides bind form bodies, JSON and XML
+// bodies are decoded as a whole and do not consult overrides.
+func WithSourceOverrides(ctx context.Context, overrides map[string]Source) context.Context {
+	return context.WithValue(ctx, sourceOverridesKey{}, overrides)
+}
+
+func sourceOverridesFrom(ctx context.Context) map[string]Source {
+	overrides, _ := ctx.Value(sourceOverridesKey{}).(map[string]Source)
+	return overrides
+}
+
+// overriddenName returns name and options field at path is bound with from source tag and reports whether field
+// source is overridden. Empty name means field is bound from other source.
+func (s *bindState) overriddenName(path, tag string, field reflect.StructField, name string, opts tagOptions) (string, tagOptions, bool) {
+	src, ok := s.overrides[path]
+	if !ok {
+		return name, opts, false
+	}
+	if tag == "form" {
+		tag = string(SourceBody)
+	}
+	if src != Source(tag) {
+		return "", "", true
+	}
+	for _, t := range overrideTags {
+		if name != "" {
+			break
+		}
+		name, opts = parseTag(field.Tag.Get(t))
+	}
+	return name, opts, true
+}
+
+// overridesTo reports whether source of any field is overridden to source
+func (s *bindState) overridesTo(source Source) bool {
+	for _, src := range s.overrides {
+		if src == source {
+			return true
+		}
+	}
+	return false
+}
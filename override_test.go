@@ -0,0 +1,63 @@
+package binding
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestBind_SourceOverrides(t *testing.T) {
+	type filter struct {
+		Version string `query:"version"`
+	}
+	type query struct {
+		Version string `query:"version"`
+		Page    int    `query:"page" header:"X-Page"`
+		Name    string `json:"name" query:"name"`
+		Filter  filter
+	}
+	overrides := map[string]Source{
+		"Version":        SourceHeader,
+		"Page":           SourceHeader,
+		"Filter.Version": SourceCookie,
+	}
+	req := newRequest(http.MethodPost, "/?version=query&page=1&name=q", MIMEApplicationJSON, `{"name":"body"}`)
+	req.Header.Set("Version", "header")
+	req.Header.Set("X-Page", "7")
+	req.AddCookie(&http.Cookie{Name: "version", Value: "cookie"})
+	req = req.WithContext(WithSourceOverrides(req.Context(), overrides))
+
+	var q query
+	if err := Bind(req, &q); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := query{Version: "header", Page: 7, Name: "body", Filter: filter{Version: "cookie"}}
+	if q != want {
+		t.Errorf("got %+v, want %+v", q, want)
+	}
+
+	// without overrides fields bind from declared sources
+	req = newRequest(http.MethodGet, "/?version=query&page=1", "", "")
+	req.Header.Set("Version", "header")
+	var d query
+	if err := Bind(req, &d); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.Version != "query" || d.Page != 1 || d.Filter.Version != "query" {
+		t.Errorf("got %+v", d)
+	}
+}
+
+func TestBindQueryParams_SourceOverrideSkipsField(t *testing.T) {
+	var q struct {
+		Version string `query:"version"`
+		Page    int    `query:"page"`
+	}
+	req := newRequest(http.MethodGet, "/?version=query&page=2", "", "")
+	req = req.WithContext(WithSourceOverrides(req.Context(), map[string]Source{"Version": SourceHeader}))
+	if err := BindQueryParams(req, &q); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if q.Version != "" || q.Page != 2 {
+		t.Errorf("got %+v", q)
+	}
+}
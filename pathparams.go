@@ -0,0 +1,47 @@
+package binding
+
+import (
+	"net/http"
+	"sync"
+)
+
+// PathParamExtractor returns path params of request matched by a router, nil when request was not routed by it
+type PathParamExtractor func(r *http.Request) map[string][]string
+
+var (
+	pathParamExtractorMu sync.RWMutex
+	pathParamExtractor   PathParamExtractor
+)
+
+// SetPathParamExtractor sets extractor of path params for routers other than chi, i.e. gorilla/mux:
+//
+//	binding.SetPathParamExtractor(func(r *http.Request) map[string][]string {
+//		vars := mux.Vars(r)
+//		if vars == nil {
+//			return nil
+//		}
+//		params := map[string][]string{}
+//		for k, v := range vars {
+//			params[k] = []string{v}
+//		}
+//		return params
+//	})
+//
+// BindPathParams uses params returned by the extractor, when it returns nil it falls back to chi and then to stdlib
+// http.Request.PathValue. Nil fn removes the extractor.
+func SetPathParamExtractor(fn PathParamExtractor) {
+	pathParamExtractorMu.Lock()
+	defer pathParamExtractorMu.Unlock()
+	pathParamExtractor = fn
+}
+
+// extractPathParams returns path params of registered extractor, nil when there is none or it did not route r
+func extractPathParams(r *http.Request) map[string][]string {
+	pathParamExtractorMu.RLock()
+	extract := pathParamExtractor
+	pathParamExtractorMu.RUnlock()
+	if extract == nil {
+		return nil
+	}
+	return extract(r)
+}
@@ -0,0 +1,230 @@
+package binding
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type postPath struct {
+	UserID int    `param:"id"`
+	Slug   string `param:"slug"`
+	Page   int    `query:"page"`
+}
+
+// serve sends GET request of target through mux and fails the test unless its handler succeeded
+func serve(t *testing.T, mux http.Handler, target string) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %q", rec.Code, rec.Body.String())
+	}
+}
+
+// bindingHandler binds requests into dest with bind, failing them with 400
+func bindingHandler(dest interface{}, bind func(r *http.Request, i interface{}) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := bind(r, dest); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+}
+
+func TestBind_ChiRouter(t *testing.T) {
+	var dest postPath
+	router := chi.NewRouter()
+	router.Get("/users/{id}/posts/{slug}", bindingHandler(&dest, Bind))
+	serve(t, router, "/users/42/posts/hello?page=3")
+	if want := (postPath{UserID: 42, Slug: "hello", Page: 3}); dest != want {
+		t.Errorf("got %+v, want %+v", dest, want)
+	}
+}
+
+func TestBind_ServeMux(t *testing.T) {
+	var dest postPath
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/{id}/posts/{slug}", bindingHandler(&dest, Bind))
+	serve(t, mux, "/users/42/posts/hello?page=3")
+	if want := (postPath{UserID: 42, Slug: "hello", Page: 3}); dest != want {
+		t.Errorf("got %+v, want %+v", dest, want)
+	}
+}
+
+func TestBindPathParams_ServeMuxNested(t *testing.T) {
+	type dest struct {
+		Org struct {
+			Name string `param:"org"`
+		}
+		Repo struct {
+			Name string `param:"repo"`
+		}
+	}
+	var d dest
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{org}/{repo}", bindingHandler(&d, BindPathParams))
+	serve(t, mux, "/acme/tools")
+	if d.Org.Name != "acme" || d.Repo.Name != "tools" {
+		t.Errorf("got %+v", d)
+	}
+}
+
+func TestBindPathParamsFor_ServeMuxMap(t *testing.T) {
+	var d map[string]string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/{id}/posts/{slug}", bindingHandler(&d, func(r *http.Request, i interface{}) error {
+		return BindPathParamsFor(r, i, "id", "slug", "missing")
+	}))
+	serve(t, mux, "/users/42/posts/hello")
+	if want := map[string]string{"id": "42", "slug": "hello"}; !reflect.DeepEqual(d, want) {
+		t.Errorf("got %v, want %v", d, want)
+	}
+}
+
+func TestBindPathParams_WithoutRouter(t *testing.T) {
+	dest := postPath{UserID: 1}
+	if err := BindPathParams(newRequest(http.MethodGet, "/users/42/posts/hello", "", ""), &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dest.UserID != 1 || dest.Slug != "" {
+		t.Errorf("got %+v", dest)
+	}
+}
+
+type fakeRouteKey struct{}
+
+// fakeExtractor returns params stored in request context under fakeRouteKey, like gorilla/mux stores its vars
+func fakeExtractor(r *http.Request) map[string][]string {
+	vars, _ := r.Context().Value(fakeRouteKey{}).(map[string]string)
+	if vars == nil {
+		return nil
+	}
+	params := map[string][]string{}
+	for k, v := range vars {
+		params[k] = []string{v}
+	}
+	return params
+}
+
+func TestSetPathParamExtractor(t *testing.T) {
+	SetPathParamExtractor(fakeExtractor)
+	defer SetPathParamExtractor(nil)
+
+	req := newRequest(http.MethodGet, "/users/42/posts/hello", "", "")
+	req = req.WithContext(context.WithValue(req.Context(), fakeRouteKey{}, map[string]string{"id": "42", "slug": "hello"}))
+	// extractor wins over stdlib path values
+	req.SetPathValue("id", "1")
+	var dest postPath
+	if err := BindPathParams(req, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dest.UserID != 42 || dest.Slug != "hello" {
+		t.Errorf("got %+v", dest)
+	}
+}
+
+func TestSetPathParamExtractor_FallsBack(t *testing.T) {
+	SetPathParamExtractor(fakeExtractor)
+	defer SetPathParamExtractor(nil)
+
+	var fromChi postPath
+	router := chi.NewRouter()
+	router.Get("/users/{id}/posts/{slug}", bindingHandler(&fromChi, BindPathParams))
+	serve(t, router, "/users/7/posts/chi")
+	if fromChi.UserID != 7 || fromChi.Slug != "chi" {
+		t.Errorf("chi fallback got %+v", fromChi)
+	}
+
+	var fromMux postPath
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/{id}/posts/{slug}", bindingHandler(&fromMux, BindPathParams))
+	serve(t, mux, "/users/8/posts/mux")
+	if fromMux.UserID != 8 || fromMux.Slug != "mux" {
+		t.Errorf("stdlib fallback got %+v", fromMux)
+	}
+}
+
+func TestSetPathParamExtractor_Remove(t *testing.T) {
+	SetPathParamExtractor(fakeExtractor)
+	SetPathParamExtractor(nil)
+
+	req := newRequest(http.MethodGet, "/", "", "")
+	req = req.WithContext(context.WithValue(req.Context(), fakeRouteKey{}, map[string]string{"id": "42"}))
+	var dest postPath
+	if err := BindPathParams(req, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dest.UserID != 0 {
+		t.Errorf("removed extractor still used: %+v", dest)
+	}
+}
+
+func TestBindPathParams_MissingRequired(t *testing.T) {
+	type dest struct {
+		ID   int    `param:"id,required"`
+		Slug string `param:"slug"`
+	}
+	testCases := []struct {
+		name    string
+		route   string
+		target  string
+		wantErr bool
+		// ServeMux redirects paths with empty segments to their clean form
+		chiOnly bool
+	}{
+		{name: "present", route: "/users/{id}/{slug}", target: "/users/1/s"},
+		{name: "empty segment", route: "/users/{id}/{slug}", target: "/users//s", wantErr: true, chiOnly: true},
+		{name: "not in route", route: "/users/{slug}", target: "/users/s", wantErr: true},
+	}
+	for _, tc := range testCases {
+		for _, router := range []string{"chi", "mux"} {
+			if tc.chiOnly && router != "chi" {
+				continue
+			}
+			t.Run(tc.name+" "+router, func(t *testing.T) {
+				var err error
+				var d dest
+				handler := func(w http.ResponseWriter, r *http.Request) { err = BindPathParams(r, &d) }
+				var mux http.Handler
+				if router == "chi" {
+					r := chi.NewRouter()
+					r.Get(tc.route, handler)
+					mux = r
+				} else {
+					m := http.NewServeMux()
+					m.HandleFunc("GET "+tc.route, handler)
+					mux = m
+				}
+				serve(t, mux, tc.target)
+				if !tc.wantErr {
+					if err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					}
+					return
+				}
+				if !errors.Is(err, ErrMissingPathParam) || !errors.Is(err, ErrRequired) {
+					t.Errorf("error = %v, want ErrMissingPathParam", err)
+				}
+				if d.ID != 0 {
+					t.Errorf("ID = %d, want zero", d.ID)
+				}
+			})
+		}
+	}
+}
+
+func TestBindPathParams_EmptyPathValue(t *testing.T) {
+	var dest struct {
+		ID int `param:"id,required"`
+	}
+	req := newRequest(http.MethodGet, "/", "", "")
+	req.SetPathValue("id", "")
+	if err := BindPathParams(req, &dest); !errors.Is(err, ErrMissingPathParam) {
+		t.Errorf("error = %v, want ErrMissingPathParam", err)
+	}
+}
@@ -0,0 +1,110 @@
+package binding
+
+import (
+	"reflect"
+	"sync"
+)
+
+// structPlan is precomputed binding metadata of struct type for a source tag. Everything in it depends only on the
+// type and tag so it is computed once and cached.
+type structPlan struct {
+	fields []fieldPlan
+}
+
+// fieldPlan describes how a single struct field is bound from a source
+type fieldPlan struct {
+	index int
+	field reflect.StructField
+	// name and opts are parsed from the source tag, name is not substituted with DefaultBinder.TagNames yet
+	name     string
+	opts     tagOptions
+	resolver string
+	// switchSpec is the `switch` tag selecting input name by request header
+	switchSpec string
+	// kind is kind of the bound value, for embedded pointers kind of the struct they point to
+	kind reflect.Kind
+	// embeddedPtr reports embedded pointer field whose pointee is bound
+	embeddedPtr bool
+	// nested reports untagged ordinary (not BindUnmarshaler) struct field bound by recursion
+	nested bool
+	// file reports field uploaded multipart files are bound into
+	file bool
+	// namespaced reports tagged struct or map field bound from keys prefixed with its name
+	namespaced bool
+	// set reports map[K]bool set field, which binds from keys prefixed with its name unless the name itself has values
+	set bool
+	// meta is the `meta` tag of form fields bound from metadata of uploaded files instead of form values
+	meta string
+}
+
+type planKey struct {
+	typ     reflect.Type
+	tag     string
+	nameTag string
+	schema  bool
+}
+
+var (
+	plans = sync.Map{} // planKey -> *structPlan
+
+	bindUnmarshalerType = reflect.TypeOf((*BindUnmarshaler)(nil)).Elem()
+)
+
+// planFor returns cached binding plan of struct type typ for source tag. Input names are taken from nameTag, which is
+// the source tag unless configured by DefaultBinder.SourceTags. With schema they are taken from gorilla/schema
+// compatible `schema` tags, see DefaultBinder.SchemaCompat.
+func planFor(typ reflect.Type, tag, nameTag string, schema bool) *structPlan {
+	key := planKey{typ: typ, tag: tag, nameTag: nameTag, schema: schema}
+	if plan, ok := plans.Load(key); ok {
+		return plan.(*structPlan)
+	}
+	plan, _ := plans.LoadOrStore(key, buildPlan(typ, tag, nameTag, schema))
+	return plan.(*structPlan)
+}
+
+func buildPlan(typ reflect.Type, tag, nameTag string, schema bool) *structPlan {
+	plan := &structPlan{}
+	for i := 0; i < typ.NumField(); i++ {
+		typeField := typ.Field(i)
+		if isReadonly(typeField, tag) {
+			continue
+		}
+		fp := fieldPlan{
+			index:      i,
+			field:      typeField,
+			resolver:   typeField.Tag.Get("resolver"),
+			switchSpec: typeField.Tag.Get("switch"),
+		}
+		fp.name, fp.opts = parseTag(typeField.Tag.Get(nameTag))
+		if fp.name == "-" && (nameTag != tag || typeField.Tag.Get(tag) == "-") {
+			// `query:"-"` excludes the field from the source like borrowed tags `json:"-"` do, `-,` names key `-`
+			continue
+		}
+		if schema {
+			// gorilla/schema skips fields tagged `-` and binds untagged fields by their name
+			fp.name, fp.opts = parseTag(typeField.Tag.Get("schema"))
+			if fp.name == "-" {
+				continue
+			}
+			if fp.name == "" && !typeField.Anonymous && typeField.IsExported() {
+				fp.name = typeField.Name
+			}
+		}
+
+		valueType := typeField.Type
+		if typeField.Anonymous && valueType.Kind() == reflect.Ptr {
+			fp.embeddedPtr = true
+			valueType = valueType.Elem()
+		}
+		fp.kind = valueType.Kind()
+		fp.nested = fp.kind == reflect.Struct && !reflect.PointerTo(valueType).Implements(bindUnmarshalerType)
+		fp.file = isFileType(typeField.Type)
+		if tag == "form" && !fp.file {
+			fp.meta = typeField.Tag.Get("meta")
+		}
+		fp.namespaced = !fp.file && fp.meta == "" && isNamespaced(typeField)
+		fp.set = isSetType(valueType) && valueType.Elem().Kind() == reflect.Bool
+		plan.fields = append(plan.fields, fp)
+	}
+	return plan
+}
@@ -0,0 +1,130 @@
+package binding
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestPlanFor_Cached(t *testing.T) {
+	typ := reflect.TypeOf(benchFields{})
+	plan := planFor(typ, "query", "query", false)
+	if again := planFor(typ, "query", "query", false); again != plan {
+		t.Error("plan of the same type and tag is built again")
+	}
+	if other := planFor(typ, "form", "form", false); other == plan {
+		t.Error("plan of another tag is shared")
+	}
+	if len(plan.fields) != typ.NumField() {
+		t.Errorf("plan has %d fields, want %d", len(plan.fields), typ.NumField())
+	}
+}
+
+func TestPlanFor_EmbeddedAndNested(t *testing.T) {
+	type dest struct {
+		EmbeddedPagination
+		*RequiredPaging
+		Filter struct {
+			Status string `query:"status"`
+		}
+		Skipped string `query:"-"`
+	}
+	plan := planFor(reflect.TypeOf(dest{}), "query", "query", false)
+	byName := map[string]fieldPlan{}
+	for _, fp := range plan.fields {
+		byName[fp.field.Name] = fp
+	}
+	if fp := byName["EmbeddedPagination"]; !fp.nested || fp.embeddedPtr {
+		t.Errorf("embedded struct plan %+v", fp)
+	}
+	if fp := byName["RequiredPaging"]; !fp.embeddedPtr || fp.kind != reflect.Struct {
+		t.Errorf("embedded pointer plan %+v", fp)
+	}
+	if fp := byName["Filter"]; !fp.nested {
+		t.Errorf("nested struct plan %+v", fp)
+	}
+	if _, ok := byName["Skipped"]; ok {
+		t.Error("field excluded by `-` is planned")
+	}
+
+	var d dest
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?page=2&size=3&status=open", "", ""), &d); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.EmbeddedPagination.Page != 2 || d.Size != 3 || d.RequiredPaging == nil || d.RequiredPaging.Page != 2 || d.Filter.Status != "open" {
+		t.Errorf("got %+v", d)
+	}
+}
+
+// BenchmarkBindQueryParams_20Fields and BenchmarkBindQueryParams_20FieldsUncached compare binding with cached plans
+// with building them for every request as before plans were cached
+func BenchmarkBindQueryParams_20Fields(b *testing.B) {
+	benchmarkBindQueryParams(b, NewBinder(WithCaseSensitive(true)), "/?f01=a&f06=1&f11=true&f13=1.5&f15=7&f17=1&f17=2&f20=9")
+}
+
+func BenchmarkBindQueryParams_20FieldsUncached(b *testing.B) {
+	binder := NewBinder(WithCaseSensitive(true))
+	req := newRequest(http.MethodGet, "/?f01=a&f06=1&f11=true&f13=1.5&f15=7&f17=1&f17=2&f20=9", "", "")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		plans.Range(func(key, _ interface{}) bool {
+			plans.Delete(key)
+			return true
+		})
+		var dest benchFields
+		if err := binder.BindQueryParams(req, &dest); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestBindQueryParams_SchemaCompat(t *testing.T) {
+	type address struct {
+		City string `schema:"city"`
+	}
+	type filter struct {
+		Name    string   `schema:"name,required" query:"other"`
+		Tags    []string `schema:"tags"`
+		Limit   int
+		Secret  string `schema:"-"`
+		Address address
+		Agent   string `header:"X-Agent"`
+	}
+	b := NewBinder(WithSchemaCompat(true))
+	req := newRequest(http.MethodGet, "/?name=n&other=o&tags=a&tags=b&limit=10&Secret=s&-=s&Address.city=NYC", "", "")
+	req.Header.Set("X-Agent", "agent")
+	var f filter
+	if err := b.BindQueryParams(req, &f); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := b.BindHeaders(req, &f); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := filter{Name: "n", Tags: []string{"a", "b"}, Limit: 10, Address: address{City: "NYC"}, Agent: "agent"}
+	if !reflect.DeepEqual(f, want) {
+		t.Errorf("got %+v, want %+v", f, want)
+	}
+
+	var indexed filter
+	if err := b.BindQueryParams(newRequest(http.MethodGet, "/?name=n&tags[1]=b&tags[0]=a", "", ""), &indexed); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(indexed.Tags, []string{"a", "b"}) {
+		t.Errorf("indexed Tags = %v", indexed.Tags)
+	}
+
+	if err := b.BindQueryParams(newRequest(http.MethodGet, "/?limit=1", "", ""), &filter{}); !errors.Is(err, ErrRequired) {
+		t.Errorf("missing required: got error %v, want ErrRequired", err)
+	}
+
+	// schema tags are ignored without the option
+	var plain filter
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?name=n&other=o&limit=10", "", ""), &plain); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if plain.Name != "o" || plain.Limit != 0 {
+		t.Errorf("got %+v", plain)
+	}
+}
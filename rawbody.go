@@ -0,0 +1,91 @@
+package binding
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+)
+
+// BindRawBody binds the whole request body into i, which is *[]byte, *string or pointer to struct with `[]byte` or
+// `string` fields tagged `body:"raw"`. Body is read limited by DefaultBinder.MaxBodySize and r.Body is replaced with
+// the buffered copy, so body can be bound again afterwards (i.e. with BindBody) and webhook signature verified over the
+// exact bytes received.
+func BindRawBody(r *http.Request, i interface{}) error {
+	return defaultBinder.BindRawBody(r, i)
+}
+
+// BindRawBody binds the whole request body into i. See package level BindRawBody.
+func (b *DefaultBinder) BindRawBody(r *http.Request, i interface{}) error {
+	s := b.newState(r)
+	return b.run(s, i, func() error {
+		targets, err := rawBodyTargets(i, true)
+		if err != nil || len(targets) == 0 {
+			return err
+		}
+		body, err := b.bufferBody(r, s)
+		if err != nil {
+			return err
+		}
+		setRawBody(targets, body)
+		return nil
+	})
+}
+
+// rawBodyTargets returns values the raw body is bound into, fields tagged `body:"raw"` of struct i or, when direct
+// is set, i itself if it points to []byte or string
+func rawBodyTargets(i interface{}, direct bool) ([]reflect.Value, error) {
+	val := reflect.ValueOf(i)
+	if val.Kind() != reflect.Ptr || val.IsNil() {
+		return nil, nil
+	}
+	val = val.Elem()
+	if direct && isRawBodyType(val.Type()) {
+		return []reflect.Value{val}, nil
+	}
+	if val.Kind() != reflect.Struct {
+		return nil, nil
+	}
+	var targets []reflect.Value
+	for n := 0; n < val.NumField(); n++ {
+		typeField := val.Type().Field(n)
+		if typeField.Tag.Get("body") != "raw" {
+			continue
+		}
+		if !isRawBodyType(typeField.Type) || !typeField.IsExported() {
+			return nil, fmt.Errorf("raw body can not be bound into field %s of type %v", typeField.Name, typeField.Type)
+		}
+		targets = append(targets, val.Field(n))
+	}
+	return targets, nil
+}
+
+func isRawBodyType(t reflect.Type) bool {
+	return t.Kind() == reflect.String || t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8
+}
+
+func setRawBody(targets []reflect.Value, body []byte) {
+	for _, target := range targets {
+		if target.Kind() == reflect.String {
+			target.SetString(string(body))
+		} else {
+			target.SetBytes(append([]byte(nil), body...))
+		}
+	}
+}
+
+// bufferBody reads request body limited by MaxBodySize and Timeout and replaces r.Body with the buffered copy
+func (b *DefaultBinder) bufferBody(r *http.Request, s *bindState) ([]byte, error) {
+	var body []byte
+	err := b.readBody(r, s, func() (err error) {
+		body, err = io.ReadAll(r.Body)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
@@ -0,0 +1,136 @@
+package binding
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestBindBody_ReusableBody(t *testing.T) {
+	type order struct {
+		ID int `json:"id"`
+	}
+	type audit struct {
+		Note string `json:"note"`
+	}
+	const body = `{"id":1,"note":"n"}`
+	req := newRequest("POST", "/", MIMEApplicationJSON, body)
+	var o order
+	var a audit
+	if err := NewBinder(WithReusableBody(true)).BindBody(req, &o); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := BindBody(req, &a); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if o.ID != 1 || a.Note != "n" {
+		t.Errorf("got %+v and %+v", o, a)
+	}
+
+	req = newRequest("POST", "/", MIMEApplicationJSON, body)
+	if err := BindBody(req, &o); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rest, _ := io.ReadAll(req.Body); len(rest) != 0 {
+		t.Errorf("body left without ReusableBody = %q", rest)
+	}
+}
+
+func TestBindBody_ReusableBodyIntoTwoStructs(t *testing.T) {
+	type order struct {
+		ID   int    `json:"id"`
+		Note string `json:"note"`
+	}
+	type audit struct {
+		ID   string `json:"id"`
+		Note string `json:"note"`
+	}
+	const body = `{"id":"a-1","note":"n"}`
+	b := NewBinder(WithReusableBody(true))
+	req := newRequest("POST", "/", MIMEApplicationJSON, body)
+
+	var o order
+	if err := b.BindBody(req, &o); err == nil {
+		t.Fatal("expected error decoding string id into int")
+	}
+	var a audit
+	if err := b.BindBody(req, &a); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a.ID != "a-1" || a.Note != "n" {
+		t.Errorf("got %+v", a)
+	}
+	rest, err := io.ReadAll(req.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(rest) != body {
+		t.Errorf("body left = %q, want %q", rest, body)
+	}
+}
+
+type signedWebhook struct {
+	Raw    []byte `body:"raw"`
+	RawStr string `body:"raw"`
+	Event  string `json:"event"`
+	Amount int    `json:"amount"`
+}
+
+func TestBindBody_RawBodyFieldsWithDecodedBody(t *testing.T) {
+	const body = `{"event":"paid", "amount":5}`
+	req := newRequest(http.MethodPost, "/", MIMEApplicationJSON, body)
+	var hook signedWebhook
+	if err := BindBody(req, &hook); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(hook.Raw) != body || hook.RawStr != body {
+		t.Errorf("raw body = %q / %q, want %q", hook.Raw, hook.RawStr, body)
+	}
+	if hook.Event != "paid" || hook.Amount != 5 {
+		t.Errorf("decoded body = %+v", hook)
+	}
+}
+
+func TestBindRawBody_ThenBindBody(t *testing.T) {
+	const body = `{"event":"paid","amount":5}`
+	req := newRequest(http.MethodPost, "/", MIMEApplicationJSON, body)
+
+	var raw []byte
+	if err := BindRawBody(req, &raw); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var rawStr string
+	if err := BindRawBody(req, &rawStr); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var hook signedWebhook
+	if err := BindBody(req, &hook); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(raw) != body || rawStr != body || hook.Event != "paid" || hook.Amount != 5 {
+		t.Errorf("got %q %q %+v", raw, rawStr, hook)
+	}
+}
+
+func TestBindRawBody_MaxBodySize(t *testing.T) {
+	b := NewBinder(WithMaxBodySize(4))
+	var raw []byte
+	if err := b.BindRawBody(newRequest(http.MethodPost, "/", "", "1234"), &raw); err != nil || string(raw) != "1234" {
+		t.Errorf("got %q, %v", raw, err)
+	}
+	req := newRequest(http.MethodPost, "/", "", "12345")
+	req.ContentLength = -1
+	if err := b.BindRawBody(req, &raw); !errors.Is(err, ErrBodyTooLarge) {
+		t.Errorf("error = %v, want ErrBodyTooLarge", err)
+	}
+}
+
+func TestBindRawBody_InvalidField(t *testing.T) {
+	var dest struct {
+		Raw int `body:"raw"`
+	}
+	if err := BindRawBody(newRequest(http.MethodPost, "/", "", "x"), &dest); err == nil {
+		t.Error("expected error for int raw body field")
+	}
+}
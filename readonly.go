@@ -0,0 +1,62 @@
+package binding
+
+import (
+	"reflect"
+	"strings"
+)
+
+// isReadonly reports whether field must not be set from source tag according to its `readonly:"query,body"` tag.
+// Source `body` covers every body decoder including form and CSV.
+func isReadonly(field reflect.StructField, tag string) bool {
+	readonly, ok := field.Tag.Lookup("readonly")
+	if !ok {
+		return false
+	}
+	for _, source := range strings.Split(readonly, ",") {
+		source = strings.TrimSpace(source)
+		if source == tag || (source == "body" && (tag == "form" || tag == "csv")) {
+			return true
+		}
+	}
+	return false
+}
+
+// preserveReadonly snapshots fields of destination struct that are readonly for body. Returned function restores them,
+// it is meant to be deferred around body decoder (json, xml etc.) that has no notion of readonly fields, so fields are
+// restored even when decoding fails half way.
+func preserveReadonly(destination interface{}) (restore func()) {
+	val := reflect.ValueOf(destination)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return func() {}
+	}
+
+	var fields, values []reflect.Value
+	var walk func(val reflect.Value)
+	walk = func(val reflect.Value) {
+		typ := val.Type()
+		for i := 0; i < typ.NumField(); i++ {
+			typeField := typ.Field(i)
+			structField := val.Field(i)
+			if !structField.CanSet() {
+				continue
+			}
+			if isReadonly(typeField, "body") {
+				saved := reflect.New(structField.Type()).Elem()
+				saved.Set(structField)
+				fields = append(fields, structField)
+				values = append(values, saved)
+				continue
+			}
+			if structField.Kind() == reflect.Struct {
+				walk(structField)
+			}
+		}
+	}
+	walk(val.Elem())
+
+	return func() {
+		for i, f := range fields {
+			f.Set(values[i])
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package binding
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+)
+
+type readonlyAccount struct {
+	ID    int    `json:"id" xml:"id" readonly:"body"`
+	Name  string `json:"name" xml:"name"`
+	Count int    `json:"count" xml:"count"`
+}
+
+func TestBind_Readonly(t *testing.T) {
+	dest := struct {
+		TenantID string `query:"tenant" json:"tenant" readonly:"query,body"`
+		Name     string `query:"name" json:"name"`
+	}{TenantID: "acme"}
+	req := newRequest(http.MethodPost, "/?tenant=evil&name=q", MIMEApplicationJSON, `{"tenant":"evil","name":"n"}`)
+	if err := Bind(req, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dest.TenantID != "acme" || dest.Name != "n" {
+		t.Errorf("got %+v", dest)
+	}
+
+	account := readonlyAccount{ID: 1}
+	for _, req := range []*http.Request{
+		newRequest(http.MethodPost, "/", MIMEApplicationJSON, `{"id":5,"name":"n"}`),
+		newRequest(http.MethodPost, "/", MIMEApplicationXML, `<a><id>5</id><name>n</name></a>`),
+		newRequest(http.MethodPost, "/", MIMEApplicationForm, "id=5&name=n"),
+	} {
+		if err := BindBody(req, &account); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if account.ID != 1 || account.Name != "n" {
+			t.Errorf("%s: got %+v", req.Header.Get(HeaderContentType), account)
+		}
+	}
+}
+
+func TestBindBody_ReadonlyRestoredOnDecodeError(t *testing.T) {
+	testCases := []struct {
+		name        string
+		contentType string
+		body        string
+	}{
+		{name: "json", contentType: MIMEApplicationJSON, body: `{"id":5,"name":"n","count":"x"}`},
+		{name: "xml", contentType: MIMEApplicationXML, body: `<a><id>5</id><name>n</name><count>x</count></a>`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dest := readonlyAccount{ID: 1}
+			if err := BindBody(newRequest("POST", "/", tc.contentType, tc.body), &dest); err == nil {
+				t.Fatal("expected error")
+			}
+			if dest.ID != 1 {
+				t.Errorf("ID = %d, want 1", dest.ID)
+			}
+		})
+	}
+}
+
+func TestBindBody_ReadonlyRestoredOnRegisteredDecoderError(t *testing.T) {
+	const mediaType = "application/x-readonly-test"
+	RegisterDecoder(mediaType, func(_ io.Reader, i interface{}) error {
+		i.(*readonlyAccount).ID = 5
+		return io.ErrUnexpectedEOF
+	})
+	defer RegisterDecoder(mediaType, nil)
+
+	dest := readonlyAccount{ID: 1}
+	if err := BindBody(newRequest("POST", "/", mediaType, "x"), &dest); err == nil {
+		t.Fatal("expected error")
+	}
+	if dest.ID != 1 {
+		t.Errorf("ID = %d, want 1", dest.ID)
+	}
+}
+
+type readonlyTenant struct {
+	TenantID string `param:"tenant,required" query:"tenant,required" json:"tenant" readonly:"query,body"`
+	Name     string `query:"name" json:"name"`
+}
+
+func TestBind_RequiredIgnoresReadonlySources(t *testing.T) {
+	testCases := []struct {
+		name       string
+		pathTenant string
+		target     string
+		body       string
+		wantErr    bool
+		wantTenant string
+	}{
+		{name: "path only", pathTenant: "acme", target: "/?name=n", wantTenant: "acme"},
+		{name: "readonly query ignored", pathTenant: "acme", target: "/?tenant=evil", wantTenant: "acme"},
+		{name: "readonly body ignored", pathTenant: "acme", target: "/", body: `{"tenant":"evil"}`, wantTenant: "acme"},
+		{name: "missing path fails", target: "/?name=n", wantErr: true},
+		{name: "readonly query does not satisfy required", target: "/?tenant=evil", wantErr: true},
+		{name: "readonly body does not satisfy required", target: "/", body: `{"tenant":"evil"}`, wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			contentType := ""
+			if tc.body != "" {
+				contentType = MIMEApplicationJSON
+			}
+			req := newRequest(http.MethodPost, tc.target, contentType, tc.body)
+			if tc.pathTenant != "" {
+				req.SetPathValue("tenant", tc.pathTenant)
+			}
+			var dest readonlyTenant
+			err := Bind(req, &dest)
+			if tc.wantErr {
+				var be *BindingError
+				if !errors.Is(err, ErrMissingPathParam) || !errors.As(err, &be) || be.Field != "tenant" {
+					t.Errorf("error = %v, want missing path param tenant", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if dest.TenantID != tc.wantTenant {
+				t.Errorf("TenantID = %q, want %q", dest.TenantID, tc.wantTenant)
+			}
+		})
+	}
+}
+
+func TestBindQueryParams_ReadonlyRequiredNotChecked(t *testing.T) {
+	var dest readonlyTenant
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?name=n", "", ""), &dest); err != nil {
+		t.Errorf("unexpected error for required field readonly for query: %v", err)
+	}
+}
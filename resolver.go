@@ -0,0 +1,34 @@
+package binding
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Resolver finds input values of a field tagged with `resolver:"<name>"`. It receives source tag being bound (i.e.
+// `query`) and all input data of that source and reports whether values for the field were found.
+type Resolver func(tag string, data map[string][]string) ([]string, bool)
+
+var (
+	resolversMu sync.RWMutex
+	resolvers   = map[string]Resolver{}
+)
+
+// RegisterResolver registers resolver for fields tagged with `resolver:"<name>"`. Resolver is consulted for every
+// source before the lookup by source tag name and wins when it finds values. Field does not need a source tag when it
+// has a resolver, in that case resolver is responsible for ignoring sources the field should not be bound from.
+func RegisterResolver(name string, fn Resolver) {
+	resolversMu.Lock()
+	defer resolversMu.Unlock()
+	resolvers[name] = fn
+}
+
+func lookupResolver(name string) (Resolver, error) {
+	resolversMu.RLock()
+	defer resolversMu.RUnlock()
+	fn, ok := resolvers[name]
+	if !ok {
+		return nil, fmt.Errorf("resolver %q is not registered", name)
+	}
+	return fn, nil
+}
@@ -0,0 +1,65 @@
+package binding
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func init() {
+	RegisterResolver("test-legacy-id", func(tag string, data map[string][]string) ([]string, bool) {
+		if tag != "query" {
+			return nil, false
+		}
+		for k, v := range data {
+			if strings.HasPrefix(k, "id_") {
+				return v, true
+			}
+		}
+		return nil, false
+	})
+}
+
+func TestBindQueryParams_Resolver(t *testing.T) {
+	type query struct {
+		ID     int `query:"id" resolver:"test-legacy-id"`
+		Legacy int `resolver:"test-legacy-id"`
+	}
+	testCases := []struct {
+		target string
+		want   query
+	}{
+		{"/?id_v1=7&id=1", query{ID: 7, Legacy: 7}},
+		{"/?id=1", query{ID: 1}},
+		{"/", query{}},
+	}
+	for _, tc := range testCases {
+		var dest query
+		if err := BindQueryParams(newRequest(http.MethodGet, tc.target, "", ""), &dest); err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.target, err)
+		}
+		if dest != tc.want {
+			t.Errorf("%s: got %+v, want %+v", tc.target, dest, tc.want)
+		}
+	}
+
+	// resolver ignores other sources
+	var dest query
+	req := newRequest(http.MethodPost, "/", MIMEApplicationForm, "id_v1=7&id=1")
+	if err := BindBody(req, &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dest != (query{}) {
+		t.Errorf("got %+v, want nothing bound from form", dest)
+	}
+}
+
+func TestBindQueryParams_ResolverNotRegistered(t *testing.T) {
+	var dest struct {
+		ID int `query:"id" resolver:"test-missing"`
+	}
+	err := BindQueryParams(newRequest(http.MethodGet, "/?id=1", "", ""), &dest)
+	if err == nil || !strings.Contains(err.Error(), `"test-missing"`) {
+		t.Errorf("got error %v, want unregistered resolver error", err)
+	}
+}
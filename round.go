@@ -0,0 +1,77 @@
+package binding
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// roundField rounds float field (also pointer or slice of floats) to decimal places of `round:"<places>[,<mode>]"`
+// tag. Mode `halfup` (default) rounds halves away from zero, `halfeven` to the even digit (banker's rounding).
+func roundField(spec string, field reflect.Value) error {
+	placesStr, mode, _ := strings.Cut(spec, ",")
+	places, err := strconv.Atoi(strings.TrimSpace(placesStr))
+	mode = strings.TrimSpace(mode)
+	if err != nil || places < 0 || mode != "" && mode != "halfup" && mode != "halfeven" {
+		return fmt.Errorf("invalid round %q, expected `places[,halfup|halfeven]`", spec)
+	}
+
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return nil
+		}
+		field = field.Elem()
+	}
+	if field.Kind() == reflect.Slice {
+		for i := 0; i < field.Len(); i++ {
+			if err := roundField(spec, field.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	bitSize := 64
+	switch field.Kind() {
+	case reflect.Float32:
+		bitSize = 32
+	case reflect.Float64:
+	default:
+		return fmt.Errorf("round can not be applied to field of type %v", field.Type())
+	}
+	field.SetFloat(roundDecimal(field.Float(), places, bitSize, mode == "halfeven"))
+	return nil
+}
+
+// roundDecimal rounds x to decimal places. Rounding works on the shortest decimal representation of x, so 2.675
+// rounds half up to 2.68 even though its binary value is slightly below.
+func roundDecimal(x float64, places, bitSize int, halfEven bool) float64 {
+	if math.IsInf(x, 0) || math.IsNaN(x) {
+		return x
+	}
+	repr := strconv.FormatFloat(math.Abs(x), 'f', -1, bitSize)
+	intPart, frac, _ := strings.Cut(repr, ".")
+	if len(frac) <= places {
+		return x
+	}
+	digits := intPart + frac[:places]
+	n, err := strconv.ParseUint(digits, 10, 64)
+	if err != nil || n >= 1<<63 {
+		// too many digits for exact rounding, fall back to float arithmetic
+		p := math.Pow10(places)
+		if halfEven {
+			return math.RoundToEven(x*p) / p
+		}
+		return math.Round(x*p) / p
+	}
+	next, rest := frac[places], strings.TrimRight(frac[places+1:], "0")
+	if next > '5' || next == '5' && (rest != "" || !halfEven || n%2 == 1) {
+		n++
+	}
+	rounded, _ := strconv.ParseFloat(strconv.FormatUint(n, 10)+"e-"+strconv.Itoa(places), 64)
+	if x < 0 {
+		rounded = -rounded
+	}
+	return rounded
+}
@@ -0,0 +1,65 @@
+package binding
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestRoundDecimal(t *testing.T) {
+	testCases := []struct {
+		x        float64
+		places   int
+		halfEven bool
+		want     float64
+	}{
+		{19.999, 2, false, 20},
+		{2.675, 2, false, 2.68},
+		{2.665, 2, true, 2.66},
+		{2.675, 2, true, 2.68},
+		{2.6651, 2, true, 2.67},
+		{-1.005, 2, false, -1.01},
+		{1.5, 0, true, 2},
+		{2.5, 0, true, 2},
+		{2.5, 0, false, 3},
+		{1.25, 3, false, 1.25},
+	}
+	for _, tc := range testCases {
+		if got := roundDecimal(tc.x, tc.places, 64, tc.halfEven); got != tc.want {
+			t.Errorf("roundDecimal(%v, %d, halfEven=%v) = %v, want %v", tc.x, tc.places, tc.halfEven, got, tc.want)
+		}
+	}
+}
+
+func TestBindQueryParams_Round(t *testing.T) {
+	type query struct {
+		Price  float64   `query:"price" round:"2"`
+		Rate   *float32  `query:"rate" round:"1,halfeven"`
+		Steps  []float64 `query:"steps" round:"0"`
+		Capped float64   `query:"capped" round:"0" clamp:"0,10.5"`
+	}
+	var q query
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?price=19.999&rate=0.25&steps=1.5&steps=-2.5&capped=10.6", "", ""), &q); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if q.Price != 20 || q.Rate == nil || *q.Rate != 0.2 || !reflect.DeepEqual(q.Steps, []float64{2, -3}) || q.Capped != 10.5 {
+		t.Errorf("got %+v", q)
+	}
+
+	testCases := []interface{}{
+		&struct {
+			V float64 `query:"v" round:"two"`
+		}{},
+		&struct {
+			V float64 `query:"v" round:"2,ceil"`
+		}{},
+		&struct {
+			V int `query:"v" round:"2"`
+		}{},
+	}
+	for _, dest := range testCases {
+		if err := BindQueryParams(newRequest(http.MethodGet, "/?v=5", "", ""), dest); err == nil {
+			t.Errorf("%T: expected error", dest)
+		}
+	}
+}
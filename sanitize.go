@@ -0,0 +1,40 @@
+package binding
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+var (
+	sanitizersMu sync.RWMutex
+	// sanitizers are HTML/script sanitizers selected by `sanitize` struct tag
+	sanitizers = map[string]func(string) string{}
+)
+
+// RegisterSanitizer registers sanitizer policy for string fields tagged with `sanitize:"<name>"`. Sanitizer is applied
+// to bound value after conversion. This package does not import any HTML sanitizer to avoid the dependency, register
+// i.e. bluemonday policy instead:
+//
+//	binding.RegisterSanitizer("strict", bluemonday.StrictPolicy().Sanitize)
+//	binding.RegisterSanitizer("ugc", bluemonday.UGCPolicy().Sanitize)
+func RegisterSanitizer(name string, fn func(string) string) {
+	sanitizersMu.Lock()
+	defer sanitizersMu.Unlock()
+	sanitizers[name] = fn
+}
+
+// sanitizeField applies sanitizer registered under name to string field. Binding fails when sanitizer is not registered
+// unless DefaultBinder.IgnoreUnknownSanitizers is set, as silently skipping sanitization would go unnoticed.
+func (s *bindState) sanitizeField(name string, field reflect.Value) error {
+	sanitizersMu.RLock()
+	fn, ok := sanitizers[name]
+	sanitizersMu.RUnlock()
+	if !ok {
+		if s.binder.IgnoreUnknownSanitizers {
+			return nil
+		}
+		return fmt.Errorf("sanitizer %q is not registered", name)
+	}
+	return mapStringField(field, func(value string) (string, error) { return fn(value), nil })
+}
@@ -0,0 +1,53 @@
+package binding
+
+import (
+	"net/http"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+var testTags = regexp.MustCompile(`<[^>]*>`)
+
+func init() {
+	RegisterSanitizer("test-strip-tags", func(s string) string { return testTags.ReplaceAllString(s, "") })
+}
+
+func TestBindQueryParams_Sanitize(t *testing.T) {
+	type query struct {
+		Body string   `query:"body" sanitize:"test-strip-tags"`
+		Ptr  *string  `query:"ptr" sanitize:"test-strip-tags"`
+		Tags []string `query:"tags" sanitize:"test-strip-tags"`
+		Raw  string   `query:"raw"`
+	}
+	var q query
+	target := "/?body=%3Cb%3Ehi%3C/b%3E&ptr=%3Ci%3Ex%3C/i%3E&tags=%3Cp%3Ea&tags=b&raw=%3Cb%3E"
+	if err := BindQueryParams(newRequest(http.MethodGet, target, "", ""), &q); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if q.Body != "hi" || q.Ptr == nil || *q.Ptr != "x" || q.Raw != "<b>" {
+		t.Errorf("got %+v", q)
+	}
+	if want := []string{"a", "b"}; !reflect.DeepEqual(q.Tags, want) {
+		t.Errorf("Tags = %v, want %v", q.Tags, want)
+	}
+}
+
+func TestBindQueryParams_UnknownSanitizer(t *testing.T) {
+	type query struct {
+		Body string `query:"body" sanitize:"test-unregistered"`
+	}
+	var q query
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?body=%3Cb%3E", "", ""), &q); err == nil {
+		t.Error("unregistered sanitizer: expected error")
+	}
+
+	b := &DefaultBinder{IgnoreUnknownSanitizers: true}
+	q = query{}
+	if err := b.BindQueryParams(newRequest(http.MethodGet, "/?body=%3Cb%3E", "", ""), &q); err != nil {
+		t.Fatalf("IgnoreUnknownSanitizers: unexpected error: %v", err)
+	}
+	if q.Body != "<b>" {
+		t.Errorf("Body = %q, want unsanitized value", q.Body)
+	}
+}
@@ -0,0 +1,126 @@
+package binding
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidSignature is matched by errors of BindSignedQuery when query signature is missing or does not verify
+var ErrInvalidSignature = errors.New("invalid query signature")
+
+const defaultQuerySignatureParam = "signature"
+
+// BindSignedQuery verifies signature of query string and binds query params the same way as BindQueryParams, for
+// tamper-proof links. Signature is carried by DefaultBinder.QuerySignatureParam (`signature` by default) and is by
+// default hex encoded HMAC-SHA256 with DefaultBinder.QuerySignatureKey over the raw query without the signature param,
+// i.e. `?id=42&exp=1700000000&signature=<hmac of "id=42&exp=1700000000">`. Missing or invalid signature, or expired
+// link with DefaultBinder.QuerySignatureExpiryParam, returns error matching ErrInvalidSignature and nothing is bound.
+func BindSignedQuery(r *http.Request, i interface{}) error {
+	return defaultBinder.BindSignedQuery(r, i)
+}
+
+// BindSignedQuery verifies signature of query string and binds query params. See package level BindSignedQuery.
+func (b *DefaultBinder) BindSignedQuery(r *http.Request, i interface{}) error {
+	s := b.newState(r)
+	return b.run(s, i, func() error { return b.bindSignedQuery(r, i, s) })
+}
+
+func (b *DefaultBinder) bindSignedQuery(r *http.Request, i interface{}, s *bindState) error {
+	param := b.QuerySignatureParam
+	if param == "" {
+		param = defaultQuerySignatureParam
+	}
+	query, signature, err := splitSignature(r.URL.RawQuery, param)
+	if err != nil {
+		return err
+	}
+	if signature == "" {
+		return fmt.Errorf("%w: missing %s param", ErrInvalidSignature, param)
+	}
+	if err := b.verifySignature(query, signature); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return err
+	}
+	if err := b.checkSignatureExpiry(values); err != nil {
+		return err
+	}
+	return bindData(s, "", i, values, "query")
+}
+
+// checkSignatureExpiry fails signed query whose DefaultBinder.QuerySignatureExpiryParam is not in the future
+func (b *DefaultBinder) checkSignatureExpiry(values url.Values) error {
+	param := b.QuerySignatureExpiryParam
+	if param == "" {
+		return nil
+	}
+	raw := values.Get(param)
+	if raw == "" {
+		return fmt.Errorf("%w: missing %s param", ErrInvalidSignature, param)
+	}
+	exp, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: malformed %s param", ErrInvalidSignature, param)
+	}
+	if expiry := time.Unix(exp, 0); !time.Now().Before(expiry) {
+		return fmt.Errorf("%w: expired at %s", ErrInvalidSignature, expiry.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
+func (b *DefaultBinder) verifySignature(query, signature string) error {
+	if b.QuerySignatureVerifier != nil {
+		return b.QuerySignatureVerifier(query, signature)
+	}
+	if len(b.QuerySignatureKey) == 0 {
+		return errors.New("query signature key is not set")
+	}
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return errors.New("malformed signature")
+	}
+	mac := hmac.New(sha256.New, b.QuerySignatureKey)
+	mac.Write([]byte(query))
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return errors.New("signature mismatch")
+	}
+	return nil
+}
+
+// splitSignature returns raw query without params named param, keeping order and escaping of the rest as signed, and
+// the (unescaped) value of the first of them
+func splitSignature(rawQuery, param string) (string, string, error) {
+	var kept []string
+	signature := ""
+	for _, pair := range strings.Split(rawQuery, "&") {
+		if pair == "" {
+			continue
+		}
+		rawKey, rawValue, _ := strings.Cut(pair, "=")
+		key, err := url.QueryUnescape(rawKey)
+		if err != nil {
+			return "", "", err
+		}
+		if key != param {
+			kept = append(kept, pair)
+			continue
+		}
+		if signature == "" {
+			if signature, err = url.QueryUnescape(rawValue); err != nil {
+				return "", "", err
+			}
+		}
+	}
+	return strings.Join(kept, "&"), signature, nil
+}
@@ -0,0 +1,104 @@
+package binding
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testSignatureKey = []byte("secret")
+
+type signedLink struct {
+	File string `query:"file"`
+	Exp  int64  `query:"exp"`
+}
+
+// signQuery returns rawQuery with its hex encoded HMAC-SHA256 appended as signature param
+func signQuery(rawQuery string) string {
+	mac := hmac.New(sha256.New, testSignatureKey)
+	mac.Write([]byte(rawQuery))
+	return rawQuery + "&signature=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestBindSignedQuery(t *testing.T) {
+	future := strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)
+	past := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+	valid := signQuery("file=report%20q1.pdf&exp=" + future)
+	signature := valid[strings.LastIndex(valid, "=")+1:]
+	testCases := []struct {
+		name    string
+		query   string
+		wantErr string
+	}{
+		{name: "valid signature", query: valid},
+		{name: "signature first", query: "signature=" + signature + "&file=report%20q1.pdf&exp=" + future},
+		{name: "tampered param", query: strings.Replace(valid, "report", "secrets", 1), wantErr: "signature mismatch"},
+		{name: "added param", query: valid + "&admin=1", wantErr: "signature mismatch"},
+		{name: "missing signature", query: "file=report%20q1.pdf&exp=" + future, wantErr: "missing signature param"},
+		{name: "malformed signature", query: "file=a&exp=" + future + "&signature=zz", wantErr: "malformed signature"},
+		{name: "expired", query: signQuery("file=a&exp=" + past), wantErr: "expired at"},
+		{name: "missing expiry", query: signQuery("file=a"), wantErr: "missing exp param"},
+		{name: "malformed expiry", query: signQuery("file=a&exp=tomorrow"), wantErr: "malformed exp param"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			binder := &DefaultBinder{QuerySignatureKey: testSignatureKey, QuerySignatureExpiryParam: "exp"}
+			var dest signedLink
+			err := binder.BindSignedQuery(newRequest(http.MethodGet, "/download?"+tc.query, "", ""), &dest)
+			if tc.wantErr != "" {
+				if !errors.Is(err, ErrInvalidSignature) || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Errorf("error = %v, want ErrInvalidSignature containing %q", err, tc.wantErr)
+				}
+				if dest != (signedLink{}) {
+					t.Errorf("bound %+v from query failing verification", dest)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if dest.File != "report q1.pdf" || strconv.FormatInt(dest.Exp, 10) != future {
+				t.Errorf("got %+v", dest)
+			}
+		})
+	}
+}
+
+func TestBindSignedQuery_Options(t *testing.T) {
+	var dest signedLink
+	err := (&DefaultBinder{}).BindSignedQuery(newRequest(http.MethodGet, "/?"+signQuery("file=a"), "", ""), &dest)
+	if !errors.Is(err, ErrInvalidSignature) || !strings.Contains(err.Error(), "key is not set") {
+		t.Errorf("error = %v, want error of missing key", err)
+	}
+
+	query := strings.Replace(signQuery("file=a"), "signature=", "sig=", 1)
+	binder := &DefaultBinder{QuerySignatureKey: testSignatureKey, QuerySignatureParam: "sig"}
+	if err := binder.BindSignedQuery(newRequest(http.MethodGet, "/?"+query, "", ""), &dest); err != nil || dest.File != "a" {
+		t.Errorf("custom param: got %+v, err = %v", dest, err)
+	}
+
+	var verified string
+	binder = &DefaultBinder{QuerySignatureVerifier: func(query, signature string) error {
+		verified = query
+		if signature != "ok" {
+			return errors.New("rejected")
+		}
+		return nil
+	}}
+	if err := binder.BindSignedQuery(newRequest(http.MethodGet, "/?file=b&signature=ok", "", ""), &dest); err != nil || dest.File != "b" {
+		t.Errorf("verifier: got %+v, err = %v", dest, err)
+	}
+	if verified != "file=b" {
+		t.Errorf("verifier called with %q, want query without signature", verified)
+	}
+	err = binder.BindSignedQuery(newRequest(http.MethodGet, "/?file=c&signature=bad", "", ""), &dest)
+	if !errors.Is(err, ErrInvalidSignature) || !strings.Contains(err.Error(), "rejected") {
+		t.Errorf("error = %v, want verifier error matching ErrInvalidSignature", err)
+	}
+}
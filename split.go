@@ -0,0 +1,36 @@
+package binding
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// splitIntoSiblings splits compound value of field tagged `split:"<sep>" into:"<Field>,<Field>..."` i.e.
+// `order_12345_v2` and binds segments into the listed sibling fields of parent struct by position with the regular
+// conversion rules. Number of segments must match number of listed fields. Compound value is a single value, repeated
+// keys fail the bind instead of dropping all but the first.
+func splitIntoSiblings(s *bindState, tag, path, sep string, typeField reflect.StructField, values []string, parent reflect.Value) error {
+	if len(values) != 1 {
+		return fmt.Errorf("field %s splits a single value, got %d", typeField.Name, len(values))
+	}
+	value := values[0]
+	into := strings.Split(typeField.Tag.Get("into"), ",")
+	segments := strings.Split(value, sep)
+	if len(segments) != len(into) {
+		return fmt.Errorf("value %q of field %s has %d segments, expected %d", value, typeField.Name, len(segments), len(into))
+	}
+	for n, name := range into {
+		name = strings.TrimSpace(name)
+		sibling, ok := parent.Type().FieldByName(name)
+		if !ok || !sibling.IsExported() {
+			return fmt.Errorf("field %s splits into unknown field %q", typeField.Name, name)
+		}
+		in := fieldInput{tag: tag, path: fieldPath(path, sibling), field: sibling, values: []string{segments[n]}}
+		target := allocPointer(parent.FieldByIndex(sibling.Index))
+		if err := setWithProperType(s, &in, target.Kind(), segments[n], target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package binding
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type compoundID struct {
+	ID      string `query:"id" split:"_" into:"Type,Number,Version"`
+	Type    string
+	Number  int
+	Version string
+}
+
+func TestBindQueryParams_SplitIntoSiblings(t *testing.T) {
+	var dest compoundID
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?id=order_12345_v2", "", ""), &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := compoundID{ID: "order_12345_v2", Type: "order", Number: 12345, Version: "v2"}
+	if dest != want {
+		t.Errorf("got %+v, want %+v", dest, want)
+	}
+}
+
+func TestBindQueryParams_SplitIntoSiblingsErrors(t *testing.T) {
+	testCases := []struct {
+		name   string
+		target string
+		want   string
+	}{
+		{name: "repeated key", target: "/?id=order_1_v1&id=invoice_2_v2", want: "splits a single value, got 2"},
+		{name: "too few segments", target: "/?id=order_1", want: "has 2 segments, expected 3"},
+		{name: "too many segments", target: "/?id=order_1_v1_x", want: "has 4 segments, expected 3"},
+		{name: "segment failing conversion", target: "/?id=order_one_v1", want: "invalid syntax"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var dest compoundID
+			err := BindQueryParams(newRequest(http.MethodGet, tc.target, "", ""), &dest)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("error = %v, want error containing %q", err, tc.want)
+			}
+		})
+	}
+}
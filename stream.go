@@ -0,0 +1,77 @@
+package binding
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// BindStream decodes JSON array request body element by element without buffering the whole array, for huge uploads
+// of records. Every element is decoded into a new value returned by newElem (a pointer, i.e. `func() interface{} {
+// return &Record{} }`) and passed to fn, which processes it before the next one is read, so memory stays flat.
+// Fields readonly for body keep values set by newElem. Elements are finished like those of BindBatch. Error returned
+// by fn stops reading and is returned as is, other errors identify the index of the element. Empty body binds nothing.
+func BindStream(r *http.Request, newElem func() interface{}, fn func(elem interface{}) error) error {
+	return defaultBinder.BindStream(r, newElem, fn)
+}
+
+// BindStream decodes JSON array request body element by element. See package level BindStream.
+func (b *DefaultBinder) BindStream(r *http.Request, newElem func() interface{}, fn func(elem interface{}) error) error {
+	return b.bindElements(r, isJSONMediaType, func(s *bindState) error {
+		return b.decodeStream(r.Body, s, newElem, fn)
+	})
+}
+
+// BindStreamOf decodes JSON array request body element by element into values of type T, see BindStream
+func BindStreamOf[T any](r *http.Request, fn func(elem *T) error) error {
+	return defaultBinder.BindStream(r, func() interface{} { return new(T) }, func(elem interface{}) error {
+		return fn(elem.(*T))
+	})
+}
+
+func (b *DefaultBinder) decodeStream(body io.Reader, s *bindState, newElem func() interface{}, fn func(elem interface{}) error) error {
+	decoder := json.NewDecoder(b.limitJSONDepth(body))
+	if b.StrictJSON {
+		decoder.DisallowUnknownFields()
+	}
+	if b.JSONUseNumber {
+		decoder.UseNumber()
+	}
+	if tok, err := decoder.Token(); err != nil {
+		if err == io.EOF {
+			return nil
+		}
+		return err
+	} else if tok != json.Delim('[') {
+		return fmt.Errorf("json: expected array, got %v", tok)
+	}
+
+	for n := 0; decoder.More(); n++ {
+		elem := newElem()
+		restore := preserveReadonly(elem)
+		if err := decoder.Decode(elem); err != nil {
+			return fmt.Errorf("element %d: %w", n, err)
+		}
+		restore()
+		if err := b.finishElement(s, elem); err != nil {
+			return fmt.Errorf("element %d: %w", n, err)
+		}
+		if err := fn(elem); err != nil {
+			return err
+		}
+	}
+	if _, err := decoder.Token(); err != nil {
+		if err == io.EOF {
+			return errors.New("json: unterminated array")
+		}
+		return err
+	}
+	if b.StrictJSON {
+		if _, err := decoder.Token(); err != io.EOF {
+			return errors.New("json: unexpected data after top-level value")
+		}
+	}
+	return nil
+}
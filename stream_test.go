@@ -0,0 +1,93 @@
+package binding
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type streamRecord struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestBindStreamOf_Malformed(t *testing.T) {
+	testCases := []struct {
+		name      string
+		body      string
+		wantSeen  int
+		wantError string
+	}{
+		{name: "object instead of array", body: `{"id":1}`, wantError: "expected array"},
+		{name: "unterminated array", body: `[{"id":1},{"id":2}`, wantSeen: 2, wantError: "element 2"},
+		{name: "truncated element", body: `[{"id":1},{"id":`, wantSeen: 1, wantError: "element 1"},
+		{name: "wrong element type", body: `[{"id":1},{"id":"x"}]`, wantSeen: 1, wantError: "element 1"},
+		{name: "missing comma", body: `[{"id":1} {"id":2}]`, wantSeen: 1},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			seen := 0
+			err := BindStreamOf(newRequest("POST", "/", MIMEApplicationJSON, tc.body), func(*streamRecord) error {
+				seen++
+				return nil
+			})
+			if err == nil || !strings.Contains(err.Error(), tc.wantError) {
+				t.Errorf("error = %v, want error containing %q", err, tc.wantError)
+			}
+			if seen != tc.wantSeen {
+				t.Errorf("processed %d elements before error, want %d", seen, tc.wantSeen)
+			}
+		})
+	}
+}
+
+func TestBindStreamOf_StopsOnCallbackError(t *testing.T) {
+	errStop := errors.New("stop")
+	var ids []int
+	body := `[{"id":1},{"id":2},{"id":3},{"id":"never decoded"}]`
+	err := BindStreamOf(newRequest("POST", "/", MIMEApplicationJSON, body), func(rec *streamRecord) error {
+		ids = append(ids, rec.ID)
+		if rec.ID == 2 {
+			return errStop
+		}
+		return nil
+	})
+	if err != errStop {
+		t.Errorf("error = %v, want callback error returned as is", err)
+	}
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Errorf("processed %v, want [1 2]", ids)
+	}
+}
+
+func TestBindStreamOf_EmptyBody(t *testing.T) {
+	called := false
+	err := BindStreamOf(newRequest("POST", "/", MIMEApplicationJSON, ""), func(*streamRecord) error {
+		called = true
+		return nil
+	})
+	if err != nil || called {
+		t.Errorf("error = %v, called = %v, want empty body to bind nothing", err, called)
+	}
+}
+
+func TestBindStream_ReadonlyKeepsNewElemValue(t *testing.T) {
+	type record struct {
+		ID    int    `json:"id"`
+		Owner string `json:"owner" readonly:"body"`
+	}
+	var got []record
+	body := `[{"id":1,"owner":"client"},{"id":2}]`
+	err := BindStream(newRequest("POST", "/", MIMEApplicationJSON, body),
+		func() interface{} { return &record{Owner: "server"} },
+		func(elem interface{}) error {
+			got = append(got, *elem.(*record))
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0].Owner != "server" || got[1].Owner != "server" || got[0].ID != 1 {
+		t.Errorf("got %+v, want readonly owner set by newElem", got)
+	}
+}
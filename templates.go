@@ -0,0 +1,110 @@
+package binding
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// templateTags are source tags placeholder names are matched against after Go field names
+var templateTags = []string{"param", "query", "header", "cookie", "form", "json", "xml"}
+
+// applyTemplates sets string fields tagged `template:"{first} {last}"` from sibling fields after binding. Placeholder
+// names a sibling by Go field name or by a name of its source tag, case-insensitively. Fields are evaluated in
+// declaration order and fields an input value was bound into are left as bound.
+func applyTemplates(s *bindState, path string, val reflect.Value) error {
+	typ := val.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		typeField := typ.Field(i)
+		structField := val.Field(i)
+		if !structField.CanSet() {
+			continue
+		}
+		fPath := fieldPath(path, typeField)
+
+		tmpl, ok := typeField.Tag.Lookup("template")
+		if !ok {
+			if structField.Kind() == reflect.Ptr && !structField.IsNil() {
+				structField = structField.Elem()
+			}
+			if structField.Kind() == reflect.Struct {
+				if err := applyTemplates(s, fPath, structField); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if s.present[fPath] {
+			continue
+		}
+		target := allocPointer(structField)
+		if target.Kind() != reflect.String {
+			return fmt.Errorf("template can not be applied to field %s of type %v", fPath, typeField.Type)
+		}
+		expanded, err := expandTemplate(tmpl, val)
+		if err != nil {
+			return fmt.Errorf("template of field %s: %w", fPath, err)
+		}
+		target.SetString(expanded)
+	}
+	return nil
+}
+
+// expandTemplate replaces `{name}` placeholders of tmpl with values of fields of val, `{{` and `}}` are literal braces
+func expandTemplate(tmpl string, val reflect.Value) (string, error) {
+	var b strings.Builder
+	for len(tmpl) > 0 {
+		i := strings.IndexAny(tmpl, "{}")
+		if i < 0 {
+			b.WriteString(tmpl)
+			break
+		}
+		b.WriteString(tmpl[:i])
+		if i+1 < len(tmpl) && tmpl[i+1] == tmpl[i] {
+			b.WriteByte(tmpl[i])
+			tmpl = tmpl[i+2:]
+			continue
+		}
+		end := strings.IndexByte(tmpl[i:], '}')
+		if tmpl[i] == '}' || end < 0 {
+			return "", fmt.Errorf("malformed template %q", tmpl)
+		}
+		name := tmpl[i+1 : i+end]
+		field, ok := templateField(val, name)
+		if !ok {
+			return "", fmt.Errorf("template references unknown field %q", name)
+		}
+		for field.Kind() == reflect.Ptr {
+			if field.IsNil() {
+				break
+			}
+			field = field.Elem()
+		}
+		if field.Kind() != reflect.Ptr {
+			fmt.Fprint(&b, field.Interface())
+		}
+		tmpl = tmpl[i+end+1:]
+	}
+	return b.String(), nil
+}
+
+// templateField returns field of val named by template placeholder
+func templateField(val reflect.Value, name string) (reflect.Value, bool) {
+	typ := val.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		if typ.Field(i).IsExported() && strings.EqualFold(typ.Field(i).Name, name) {
+			return val.Field(i), true
+		}
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		if !typ.Field(i).IsExported() {
+			continue
+		}
+		for _, tag := range templateTags {
+			if tagName, _ := parseTag(typ.Field(i).Tag.Get(tag)); tagName != "" && strings.EqualFold(tagName, name) {
+				return val.Field(i), true
+			}
+		}
+	}
+	return reflect.Value{}, false
+}
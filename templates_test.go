@@ -0,0 +1,63 @@
+package binding
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestBindQueryParams_Template(t *testing.T) {
+	type address struct {
+		City    string `query:"city"`
+		Country string `query:"country"`
+		Label   string `template:"{City}, {country}"`
+	}
+	type person struct {
+		First   string  `query:"first"`
+		Last    string  `query:"last"`
+		Age     int     `query:"age"`
+		Nick    *string `query:"nick"`
+		Full    string  `query:"full" template:"{first} {LAST}"`
+		Summary *string `template:"{{{full}}} ({age}) {nick}"`
+		Address address
+	}
+	var p person
+	target := "/?first=Ada&last=Lovelace&age=36&city=London&country=UK"
+	if err := BindQueryParams(newRequest(http.MethodGet, target, "", ""), &p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Full != "Ada Lovelace" || p.Summary == nil || *p.Summary != "{Ada Lovelace} (36) " ||
+		p.Address.Label != "London, UK" {
+		t.Errorf("got %+v", p)
+	}
+
+	var bound person
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?first=Ada&full=Countess", "", ""), &bound); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bound.Full != "Countess" {
+		t.Errorf("Full = %q, want the bound value", bound.Full)
+	}
+}
+
+func TestBindQueryParams_TemplateErrors(t *testing.T) {
+	var unknown struct {
+		Full string `template:"{first} {middle}"`
+	}
+	if err := BindQueryParams(newRequest(http.MethodGet, "/", "", ""), &unknown); err == nil {
+		t.Error("unknown field: expected error")
+	}
+	var malformed struct {
+		First string `query:"first"`
+		Full  string `template:"{first"`
+	}
+	if err := BindQueryParams(newRequest(http.MethodGet, "/", "", ""), &malformed); err == nil {
+		t.Error("malformed template: expected error")
+	}
+	var notString struct {
+		First string `query:"first"`
+		Count int    `template:"{first}"`
+	}
+	if err := BindQueryParams(newRequest(http.MethodGet, "/", "", ""), &notString); err == nil {
+		t.Error("non-string field: expected error")
+	}
+}
@@ -0,0 +1,43 @@
+package binding
+
+import (
+	"net"
+	"reflect"
+	"strings"
+)
+
+// formatTenant binds tenant from header value i.e. `X-Tenant: acme`, without the header (or with empty one) from the
+// leftmost label of request host under DefaultBinder.TenantBaseDomain, `acme` for `acme.example.com`. Field is left
+// untouched when neither yields a tenant.
+func formatTenant(s *bindState, in fieldInput, field reflect.Value) error {
+	for _, v := range in.values {
+		if v = strings.TrimSpace(v); v != "" {
+			in.values = []string{v}
+			return setField(s, in, field)
+		}
+	}
+	tenant := s.binder.hostTenant(s.req.Host)
+	if tenant == "" {
+		return nil
+	}
+	in.values = []string{tenant}
+	return setField(s, in, field)
+}
+
+// hostTenant returns leftmost label of host under DefaultBinder.TenantBaseDomain, empty when host is not its subdomain
+func (b *DefaultBinder) hostTenant(host string) string {
+	base := strings.ToLower(strings.Trim(b.TenantBaseDomain, "."))
+	if base == "" {
+		return ""
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	sub, ok := strings.CutSuffix(host, "."+base)
+	if !ok || sub == "" {
+		return ""
+	}
+	tenant, _, _ := strings.Cut(sub, ".")
+	return tenant
+}
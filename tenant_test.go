@@ -0,0 +1,55 @@
+package binding
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestBindHeaders_Tenant(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		host   string
+		want   string
+	}{
+		{name: "header wins", header: "acme", host: "other.example.com", want: "acme"},
+		{name: "subdomain", host: "acme.example.com", want: "acme"},
+		{name: "nested subdomain with port", host: "ACME.eu.example.com:8080", want: "acme"},
+		{name: "base domain", host: "example.com", want: ""},
+		{name: "other domain", host: "acme.example.org", want: ""},
+	}
+	b := NewBinder(WithTenantBaseDomain("example.com"))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var dest struct {
+				Tenant string `header:"X-Tenant" format:"tenant"`
+			}
+			req := newRequest(http.MethodGet, "/", "", "")
+			req.Header = http.Header{}
+			req.Host = tt.host
+			if tt.header != "" {
+				req.Header.Set("X-Tenant", tt.header)
+			}
+			if err := b.BindHeaders(req, &dest); err != nil {
+				t.Fatal(err)
+			}
+			if dest.Tenant != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, dest.Tenant)
+			}
+		})
+	}
+}
+
+func TestBindHeaders_TenantWithoutBaseDomain(t *testing.T) {
+	var dest struct {
+		Tenant *string `header:"X-Tenant" format:"tenant"`
+	}
+	req := newRequest(http.MethodGet, "/", "", "")
+	req.Host = "acme.example.com"
+	if err := BindHeaders(req, &dest); err != nil {
+		t.Fatal(err)
+	}
+	if dest.Tenant != nil {
+		t.Fatalf("expected field untouched, got %q", *dest.Tenant)
+	}
+}
@@ -0,0 +1,154 @@
+package binding
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// setTimeField parses value into time.Time or time.Duration field (or pointer to them) and reports false for fields of
+// other types. Time is parsed with layout of field `time_format` (or `format`) tag, RFC3339 by default, as HTTP date
+// with `time_format:"http"` or by LocaleParser for fields with locale. Durations are parsed as ISO 8601 with
+// `time_format:"iso8601-duration"`. Empty value leaves the field untouched.
+func (s *bindState) setTimeField(in *fieldInput, val string, field reflect.Value) (bool, error) {
+	typ := field.Type()
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ != timeType && typ != durationType {
+		return false, nil
+	}
+	if val == "" {
+		return true, nil
+	}
+	timeFormat := in.field.Tag.Get("time_format")
+	if typ == durationType && timeFormat == "iso8601-duration" {
+		d, err := parseISODuration(val)
+		if err != nil {
+			return true, fmt.Errorf("invalid duration %q: %w", val, err)
+		}
+		allocPointer(field).SetInt(int64(d))
+		return true, nil
+	}
+	if typ == durationType {
+		d, err := time.ParseDuration(val)
+		if err != nil {
+			// plain integers are nanoseconds as for any other int64 field
+			n, nErr := strconv.ParseInt(val, 10, 64)
+			if nErr != nil {
+				return true, fmt.Errorf("invalid duration %q: %w", val, err)
+			}
+			d = time.Duration(n)
+		}
+		allocPointer(field).SetInt(int64(d))
+		return true, nil
+	}
+
+	if ok, err := s.setLocaleTime(in, val, field); ok {
+		return true, err
+	}
+	if timeFormat == "http" {
+		// HTTP dates of headers like If-Modified-Since, in any of the formats HTTP/1.1 servers must accept
+		t, err := http.ParseTime(val)
+		if err != nil {
+			return true, fmt.Errorf("invalid time %q, expected HTTP date: %w", val, err)
+		}
+		allocPointer(field).Set(reflect.ValueOf(t))
+		return true, nil
+	}
+	layout := timeFormat
+	if layout == "" {
+		layout = in.field.Tag.Get("format")
+	}
+	if _, ok := formats[layout]; ok || layout == "" {
+		layout = time.RFC3339
+	} else if _, ok := formatValidators[layout]; ok {
+		layout = time.RFC3339
+	}
+	t, err := time.Parse(layout, val)
+	if err != nil {
+		return true, fmt.Errorf("invalid time %q, expected layout %q: %w", val, layout, err)
+	}
+	allocPointer(field).Set(reflect.ValueOf(t))
+	return true, nil
+}
+
+// parseISODuration parses ISO 8601 duration of weeks, days, hours, minutes and seconds, i.e. `P1DT2H30M` or
+// `PT0.5S`, optionally with leading sign. Days are 24 hours. Years and months have no fixed length and are rejected,
+// only seconds can have fraction.
+func parseISODuration(value string) (time.Duration, error) {
+	s, neg := value, false
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		neg, s = s[0] == '-', s[1:]
+	}
+	if !strings.HasPrefix(s, "P") || len(s) == 1 || strings.HasSuffix(s, "T") {
+		return 0, errors.New("expected ISO 8601 duration like P1DT2H")
+	}
+	s = s[1:]
+	var total float64
+	inTime, last := false, ""
+	for s != "" {
+		if s[0] == 'T' {
+			if inTime {
+				return 0, errors.New("repeated T designator")
+			}
+			inTime, last, s = true, "", s[1:]
+			continue
+		}
+		i := 0
+		for i < len(s) && (s[i] >= '0' && s[i] <= '9' || s[i] == '.' || s[i] == ',') {
+			i++
+		}
+		if i == 0 || i == len(s) {
+			return 0, errors.New("expected number followed by designator")
+		}
+		number, designator := strings.Replace(s[:i], ",", ".", 1), string(s[i])
+		if inTime {
+			designator = "T" + designator
+		}
+		s = s[i+1:]
+		unit, order := isoDurationUnits[designator], strings.Index("WDTHTMTS", designator)
+		if unit == 0 {
+			if designator == "Y" || designator == "M" {
+				return 0, errors.New("years and months have no fixed duration")
+			}
+			return 0, fmt.Errorf("unsupported designator %q", designator[len(designator)-1:])
+		}
+		if last != "" && order <= strings.Index("WDTHTMTS", last) {
+			return 0, fmt.Errorf("designator %q out of order", designator[len(designator)-1:])
+		}
+		if strings.Contains(number, ".") && (designator != "TS" || s != "") {
+			return 0, errors.New("only seconds can have fraction")
+		}
+		n, err := strconv.ParseFloat(number, 64)
+		if err != nil {
+			return 0, err
+		}
+		total += n * float64(unit)
+		last = designator
+	}
+	if total > math.MaxInt64 {
+		return 0, errors.New("duration out of range")
+	}
+	d := time.Duration(math.Round(total))
+	if neg {
+		d = -d
+	}
+	return d, nil
+}
+
+// isoDurationUnits are lengths of ISO 8601 duration designators, time designators are prefixed with `T`
+var isoDurationUnits = map[string]time.Duration{
+	"W":  7 * 24 * time.Hour,
+	"D":  24 * time.Hour,
+	"TH": time.Hour,
+	"TM": time.Minute,
+	"TS": time.Second,
+}
@@ -0,0 +1,234 @@
+package binding
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+type timeDest struct {
+	Since   time.Time      `query:"since" param:"since" header:"Since" form:"since"`
+	Day     time.Time      `query:"day" param:"day" header:"Day" form:"day" format:"2006-01-02"`
+	Timeout time.Duration  `query:"timeout" param:"timeout" header:"Timeout" form:"timeout"`
+	Until   *time.Time     `query:"until" param:"until" header:"Until" form:"until" format:"02.01.2006 15:04"`
+	Delay   *time.Duration `query:"delay" param:"delay" header:"Delay" form:"delay"`
+}
+
+var timeInput = map[string]string{
+	"since":   "2024-01-02T15:04:05+02:00",
+	"day":     "2024-03-04",
+	"timeout": "1m30s",
+	"until":   "05.06.2024 07:08",
+	"delay":   "250ms",
+}
+
+func checkTimeDest(t *testing.T, d timeDest) {
+	t.Helper()
+	if want := time.Date(2024, 1, 2, 13, 4, 5, 0, time.UTC); !d.Since.Equal(want) {
+		t.Errorf("Since = %v, want %v", d.Since, want)
+	}
+	if want := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC); !d.Day.Equal(want) {
+		t.Errorf("Day = %v, want %v", d.Day, want)
+	}
+	if d.Timeout != 90*time.Second {
+		t.Errorf("Timeout = %v, want 1m30s", d.Timeout)
+	}
+	if want := time.Date(2024, 6, 5, 7, 8, 0, 0, time.UTC); d.Until == nil || !d.Until.Equal(want) {
+		t.Errorf("Until = %v, want %v", d.Until, want)
+	}
+	if d.Delay == nil || *d.Delay != 250*time.Millisecond {
+		t.Errorf("Delay = %v, want 250ms", d.Delay)
+	}
+}
+
+func TestBind_TimeFromEverySource(t *testing.T) {
+	t.Run("query", func(t *testing.T) {
+		query := url.Values{}
+		for k, v := range timeInput {
+			query.Set(k, v)
+		}
+		var d timeDest
+		if err := BindQueryParams(newRequest(http.MethodGet, "/?"+query.Encode(), "", ""), &d); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checkTimeDest(t, d)
+	})
+	t.Run("param", func(t *testing.T) {
+		req := newRequest(http.MethodGet, "/", "", "")
+		for k, v := range timeInput {
+			req.SetPathValue(k, v)
+		}
+		var d timeDest
+		if err := BindPathParams(req, &d); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checkTimeDest(t, d)
+	})
+	t.Run("header", func(t *testing.T) {
+		req := newRequest(http.MethodGet, "/", "", "")
+		for k, v := range timeInput {
+			req.Header.Set(k, v)
+		}
+		var d timeDest
+		if err := BindHeaders(req, &d); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checkTimeDest(t, d)
+	})
+	t.Run("form", func(t *testing.T) {
+		pairs := make([]string, 0, 2*len(timeInput))
+		for k, v := range timeInput {
+			pairs = append(pairs, k, v)
+		}
+		var d timeDest
+		if err := BindBody(newMultipartRequest(t, "/", pairs, nil), &d); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checkTimeDest(t, d)
+	})
+}
+
+func TestBindQueryParams_EmptyTimeLeavesZero(t *testing.T) {
+	var d timeDest
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?since=&day=&timeout=&until=&delay=", "", ""), &d); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !d.Since.IsZero() || !d.Day.IsZero() || d.Timeout != 0 || d.Until != nil || d.Delay != nil {
+		t.Errorf("got %+v, want zero value", d)
+	}
+}
+
+func TestBindQueryParams_InvalidTime(t *testing.T) {
+	testCases := []struct {
+		query string
+		want  string
+	}{
+		{query: "day=04.03.2024", want: `expected layout "2006-01-02"`},
+		{query: "since=2024-01-02", want: `expected layout "` + time.RFC3339 + `"`},
+		{query: "timeout=soon", want: `invalid duration "soon"`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			var d timeDest
+			err := BindQueryParams(newRequest(http.MethodGet, "/?"+tc.query, "", ""), &d)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("error = %v, want it to contain %s", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseISODuration(t *testing.T) {
+	testCases := []struct {
+		value   string
+		want    time.Duration
+		wantErr string
+	}{
+		{value: "PT1H30M", want: 90 * time.Minute},
+		{value: "P1D", want: 24 * time.Hour},
+		{value: "P1DT2H", want: 26 * time.Hour},
+		{value: "P2W", want: 14 * 24 * time.Hour},
+		{value: "PT0.5S", want: 500 * time.Millisecond},
+		{value: "PT1M0,25S", want: time.Minute + 250*time.Millisecond},
+		{value: "PT1.000000001S", want: time.Second + 1},
+		{value: "-PT15M", want: -15 * time.Minute},
+		{value: "+PT15M", want: 15 * time.Minute},
+		{value: "P0D", want: 0},
+		{value: "", wantErr: "expected ISO 8601 duration"},
+		{value: "P", wantErr: "expected ISO 8601 duration"},
+		{value: "PT", wantErr: "expected ISO 8601 duration"},
+		{value: "1H", wantErr: "expected ISO 8601 duration"},
+		{value: "P1Y", wantErr: "no fixed duration"},
+		{value: "P1M", wantErr: "no fixed duration"},
+		{value: "PT1X", wantErr: `unsupported designator "X"`},
+		{value: "PT1H2", wantErr: "expected number followed by designator"},
+		{value: "PTH", wantErr: "expected number followed by designator"},
+		{value: "PT1M1H", wantErr: `designator "H" out of order`},
+		{value: "P1DT1HT1M", wantErr: "repeated T designator"},
+		{value: "PT1.5H", wantErr: "only seconds can have fraction"},
+		{value: "PT1..5S", wantErr: "invalid syntax"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			got, err := parseISODuration(tc.value)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Errorf("error = %v, want error containing %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tc.want {
+				t.Errorf("got %v, %v, want %v", got, err, tc.want)
+			}
+		})
+	}
+}
+
+func TestBindQueryParams_ISODuration(t *testing.T) {
+	var dest struct {
+		TTL   time.Duration  `query:"ttl" time_format:"iso8601-duration"`
+		Grace *time.Duration `query:"grace" time_format:"iso8601-duration"`
+		Plain time.Duration  `query:"plain"`
+	}
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?ttl=PT1H30M&grace=PT0.5S&plain=1m", "", ""), &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dest.TTL != 90*time.Minute || dest.Grace == nil || *dest.Grace != 500*time.Millisecond || dest.Plain != time.Minute {
+		t.Errorf("got %+v", dest)
+	}
+	err := BindQueryParams(newRequest(http.MethodGet, "/?ttl=P1M", "", ""), &dest)
+	if err == nil || !strings.Contains(err.Error(), `invalid duration "P1M"`) {
+		t.Errorf("error = %v, want invalid duration error", err)
+	}
+}
+
+func TestBindHeaders_HTTPDate(t *testing.T) {
+	want := time.Date(1994, 11, 6, 8, 49, 37, 0, time.UTC)
+	testCases := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "RFC 1123", value: "Sun, 06 Nov 1994 08:49:37 GMT"},
+		{name: "RFC 850", value: "Sunday, 06-Nov-94 08:49:37 GMT"},
+		{name: "ANSI C", value: "Sun Nov  6 08:49:37 1994"},
+		{name: "RFC 3339 rejected", value: "1994-11-06T08:49:37Z", wantErr: true},
+		{name: "garbage rejected", value: "yesterday", wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var dest struct {
+				Since *time.Time `header:"If-Modified-Since" time_format:"http"`
+			}
+			req := newRequest(http.MethodGet, "/", "", "")
+			req.Header.Set("If-Modified-Since", tc.value)
+			err := BindHeaders(req, &dest)
+			if tc.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "expected HTTP date") {
+					t.Errorf("error = %v, want HTTP date error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if dest.Since == nil || !dest.Since.Equal(want) {
+				t.Errorf("Since = %v, want %v", dest.Since, want)
+			}
+		})
+	}
+}
+
+func TestBindQueryParams_TimeFormatLayout(t *testing.T) {
+	var dest struct {
+		Day time.Time `query:"day" time_format:"2006-01-02"`
+	}
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?day=2024-03-04", "", ""), &dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC); !dest.Day.Equal(want) {
+		t.Errorf("Day = %v, want %v", dest.Day, want)
+	}
+}
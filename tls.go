@@ -0,0 +1,53 @@
+package binding
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+)
+
+// BindTLS binds attributes of the TLS client certificate (first of r.TLS.PeerCertificates) to fields tagged with
+// `tls:"<attribute>"`. Supported attributes are `subject_cn`, `subject_o`, `subject_ou`, `issuer_cn`, `serial`
+// (decimal), `fingerprint` (hex SHA-256 of the DER certificate), `san_dns`, `san_email`, `san_ip` and `san_uri`.
+// Attributes with multiple values bind into slices. Binds nothing when request has no TLS client certificate.
+func BindTLS(r *http.Request, i interface{}) error {
+	return defaultBinder.BindTLS(r, i)
+}
+
+// BindTLS binds attributes of the TLS client certificate to bindable object. See package level BindTLS.
+func (b *DefaultBinder) BindTLS(r *http.Request, i interface{}) error {
+	s := b.newState(r)
+	return b.run(s, i, func() error { return b.bindTLS(r, i, s) })
+}
+
+func (b *DefaultBinder) bindTLS(r *http.Request, i interface{}, s *bindState) error {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return nil
+	}
+	cert := r.TLS.PeerCertificates[0]
+	fingerprint := sha256.Sum256(cert.Raw)
+
+	params := map[string][]string{
+		"subject_cn":  {cert.Subject.CommonName},
+		"subject_o":   cert.Subject.Organization,
+		"subject_ou":  cert.Subject.OrganizationalUnit,
+		"issuer_cn":   {cert.Issuer.CommonName},
+		"serial":      {cert.SerialNumber.String()},
+		"fingerprint": {hex.EncodeToString(fingerprint[:])},
+		"san_dns":     cert.DNSNames,
+		"san_email":   cert.EmailAddresses,
+	}
+	for _, ip := range cert.IPAddresses {
+		params["san_ip"] = append(params["san_ip"], ip.String())
+	}
+	for _, uri := range cert.URIs {
+		params["san_uri"] = append(params["san_uri"], uri.String())
+	}
+	for k, v := range params {
+		// absent attributes leave fields untouched like missing keys of other sources
+		if len(v) == 0 || len(v) == 1 && v[0] == "" {
+			delete(params, k)
+		}
+	}
+	return bindData(s, "", i, params, "tls")
+}
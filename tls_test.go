@@ -0,0 +1,76 @@
+package binding
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestBindTLS(t *testing.T) {
+	type peer struct {
+		CN          string   `tls:"subject_cn"`
+		Org         []string `tls:"subject_o"`
+		Issuer      string   `tls:"issuer_cn"`
+		Serial      int64    `tls:"serial"`
+		Fingerprint string   `tls:"fingerprint"`
+		DNSNames    []string `tls:"san_dns"`
+		IPs         []string `tls:"san_ip"`
+		URI         string   `tls:"san_uri"`
+		Email       string   `tls:"san_email"`
+		Unit        string   `tls:"subject_ou"`
+	}
+	spiffe, _ := url.Parse("spiffe://example.org/billing")
+	cert := &x509.Certificate{
+		Raw:          []byte("der"),
+		Subject:      pkix.Name{CommonName: "billing", Organization: []string{"Example", "Example Ltd"}},
+		Issuer:       pkix.Name{CommonName: "Example CA"},
+		SerialNumber: big.NewInt(4242),
+		DNSNames:     []string{"billing.internal", "billing"},
+		IPAddresses:  []net.IP{net.ParseIP("10.0.0.1")},
+		URIs:         []*url.URL{spiffe},
+	}
+	sum := sha256.Sum256(cert.Raw)
+
+	req := newRequest(http.MethodGet, "/", "", "")
+	req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+	p := peer{Unit: "kept", Email: "kept"}
+	if err := BindTLS(req, &p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := peer{
+		CN:          "billing",
+		Org:         []string{"Example", "Example Ltd"},
+		Issuer:      "Example CA",
+		Serial:      4242,
+		Fingerprint: hex.EncodeToString(sum[:]),
+		DNSNames:    []string{"billing.internal", "billing"},
+		IPs:         []string{"10.0.0.1"},
+		URI:         "spiffe://example.org/billing",
+		Email:       "kept",
+		Unit:        "kept",
+	}
+	if !reflect.DeepEqual(p, want) {
+		t.Errorf("got %+v, want %+v", p, want)
+	}
+
+	// no client certificate
+	var empty peer
+	if err := BindTLS(newRequest(http.MethodGet, "/", "", ""), &empty); err != nil {
+		t.Fatalf("without TLS: %v", err)
+	}
+	req.TLS = &tls.ConnectionState{}
+	if err := BindTLS(req, &empty); err != nil {
+		t.Fatalf("without peer certificates: %v", err)
+	}
+	if !reflect.DeepEqual(empty, peer{}) {
+		t.Errorf("bound %+v without client certificate", empty)
+	}
+}
@@ -0,0 +1,87 @@
+package binding
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// byteUnits are multipliers of byte size suffixes, SI (powers of 1000) and IEC (powers of 1024). Longer suffixes are
+// listed first so `KiB` is not mistaken for `B`.
+var byteUnits = []struct {
+	suffix     string
+	multiplier float64
+}{
+	{"KiB", 1 << 10}, {"MiB", 1 << 20}, {"GiB", 1 << 30}, {"TiB", 1 << 40}, {"PiB", 1 << 50}, {"EiB", 1 << 60},
+	{"KB", 1e3}, {"MB", 1e6}, {"GB", 1e9}, {"TB", 1e12}, {"PB", 1e15}, {"EB", 1e18},
+	{"B", 1},
+}
+
+// applyUnit converts values of field tagged with `unit:"<unit>"` into plain numbers bound with the regular conversion
+// rules, see parseByteSize and parsePercent for supported units
+func applyUnit(unit string, values []string) ([]string, error) {
+	unit, mode, _ := strings.Cut(unit, ",")
+	var parse func(string) (string, error)
+	switch {
+	case unit == "bytes" && mode == "":
+		parse = func(v string) (string, error) {
+			n, err := parseByteSize(v)
+			return strconv.FormatUint(n, 10), err
+		}
+	case unit == "percent" && (mode == "" || mode == "whole"):
+		parse = func(v string) (string, error) { return parsePercent(v, mode == "whole") }
+	default:
+		return nil, fmt.Errorf("unknown unit %q", unit)
+	}
+
+	converted := make([]string, len(values))
+	for i, v := range values {
+		if strings.TrimSpace(v) == "" {
+			// left for the field conversion, i.e. pointer stays nil
+			continue
+		}
+		c, err := parse(v)
+		if err != nil {
+			return nil, err
+		}
+		converted[i] = c
+	}
+	return converted, nil
+}
+
+// parsePercent parses percentage i.e. `25%` or `25` as fraction `0.25`, or as `25` when whole is set
+func parsePercent(value string, whole bool) (string, error) {
+	v := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(value), "%"))
+	n, err := strconv.ParseFloat(v, 64)
+	if err != nil || math.IsInf(n, 0) || math.IsNaN(n) {
+		return "", fmt.Errorf("%w: %q is not a percentage", ErrInvalidFormat, value)
+	}
+	if whole {
+		return v, nil
+	}
+	return strconv.FormatFloat(n/100, 'g', -1, 64), nil
+}
+
+// parseByteSize parses human readable byte size i.e. `10MB` (10 000 000), `1.5 KiB` (1536) or plain `512`. Suffixes
+// are case-insensitive, fractional sizes are rounded to whole bytes.
+func parseByteSize(value string) (uint64, error) {
+	v := strings.TrimSpace(value)
+	multiplier := 1.0
+	for _, unit := range byteUnits {
+		if len(v) > len(unit.suffix) && strings.EqualFold(v[len(v)-len(unit.suffix):], unit.suffix) {
+			multiplier = unit.multiplier
+			v = strings.TrimSpace(v[:len(v)-len(unit.suffix)])
+			break
+		}
+	}
+	n, err := strconv.ParseFloat(v, 64)
+	if err != nil || n < 0 || strings.ContainsAny(v, "eEnNxX") {
+		return 0, fmt.Errorf("%w: %q is not a byte size (units B, KB, MB, GB, TB, PB, EB, KiB, MiB, GiB, TiB, PiB, EiB)", ErrInvalidFormat, value)
+	}
+	size := math.Round(n * multiplier)
+	if size >= math.MaxUint64 {
+		return 0, fmt.Errorf("%w: byte size %q is out of range", ErrInvalidFormat, value)
+	}
+	return uint64(size), nil
+}
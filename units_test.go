@@ -0,0 +1,99 @@
+package binding
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestParseByteSize(t *testing.T) {
+	testCases := []struct {
+		value string
+		want  uint64
+	}{
+		{"512", 512},
+		{"10MB", 10000000},
+		{"10 mb", 10000000},
+		{"1.5KiB", 1536},
+		{"2 GiB", 2 << 30},
+		{"1kb", 1000},
+		{"7B", 7},
+		{"0.4B", 0},
+		{"1EiB", 1 << 60},
+	}
+	for _, tc := range testCases {
+		got, err := parseByteSize(tc.value)
+		if err != nil || got != tc.want {
+			t.Errorf("parseByteSize(%q) = %d, %v, want %d", tc.value, got, err, tc.want)
+		}
+	}
+	for _, value := range []string{"10XB", "-1KB", "1e3", "NaN", "KB", "20EiB", "0x10"} {
+		if _, err := parseByteSize(value); !errors.Is(err, ErrInvalidFormat) {
+			t.Errorf("parseByteSize(%q): got error %v, want ErrInvalidFormat", value, err)
+		}
+	}
+}
+
+func TestBindQueryParams_UnitBytes(t *testing.T) {
+	type query struct {
+		MaxSize int64    `query:"maxsize" unit:"bytes"`
+		Limit   *uint32  `query:"limit" unit:"bytes"`
+		Parts   []uint64 `query:"parts" unit:"bytes"`
+		Small   uint8    `query:"small" unit:"bytes"`
+	}
+	var q query
+	target := "/?maxsize=10MB&limit=&parts=1KiB&parts=" + url.QueryEscape("2 KB")
+	if err := BindQueryParams(newRequest(http.MethodGet, target, "", ""), &q); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if q.MaxSize != 10000000 || q.Limit != nil || len(q.Parts) != 2 || q.Parts[0] != 1024 || q.Parts[1] != 2000 {
+		t.Errorf("got %+v", q)
+	}
+
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?small=1KB", "", ""), &query{}); err == nil ||
+		errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("size not fitting field: got error %v, want conversion error", err)
+	}
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?maxsize=lots", "", ""), &query{}); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("invalid size: got error %v, want ErrInvalidFormat", err)
+	}
+	var unknown struct {
+		Size int `query:"size" unit:"parsecs"`
+	}
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?size=1", "", ""), &unknown); err == nil {
+		t.Error("unknown unit: expected error")
+	}
+}
+
+func TestBindQueryParams_UnitPercent(t *testing.T) {
+	type query struct {
+		Rate     float64   `query:"rate" unit:"percent"`
+		Plain    float32   `query:"plain" unit:"percent"`
+		Whole    int       `query:"whole" unit:"percent,whole"`
+		Discount *float64  `query:"discount" unit:"percent"`
+		Steps    []float64 `query:"steps" unit:"percent"`
+	}
+	var q query
+	target := "/?rate=25%25&plain=50&whole=" + url.QueryEscape(" 30 %") + "&discount=&steps=1.5%25&steps=-10"
+	if err := BindQueryParams(newRequest(http.MethodGet, target, "", ""), &q); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if q.Rate != 0.25 || q.Plain != 0.5 || q.Whole != 30 || q.Discount != nil || len(q.Steps) != 2 ||
+		q.Steps[0] != 0.015 || q.Steps[1] != -0.1 {
+		t.Errorf("got %+v", q)
+	}
+
+	for _, value := range []string{"abc%25", "Inf", "%25"} {
+		err := BindQueryParams(newRequest(http.MethodGet, "/?rate="+value, "", ""), &query{})
+		if !errors.Is(err, ErrInvalidFormat) {
+			t.Errorf("%q: got error %v, want ErrInvalidFormat", value, err)
+		}
+	}
+	var bytesMode struct {
+		Size int `query:"size" unit:"bytes,whole"`
+	}
+	if err := BindQueryParams(newRequest(http.MethodGet, "/?size=1", "", ""), &bytesMode); err == nil {
+		t.Error("unknown unit mode: expected error")
+	}
+}
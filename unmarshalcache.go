@@ -0,0 +1,33 @@
+package binding
+
+import "reflect"
+
+// UnmarshalCache stores values produced by BindUnmarshaler and ContextBindUnmarshaler implementations keyed by their
+// type and input value. Implementations control eviction (i.e. LRU or TTL) and must be safe for concurrent use.
+// Cached values are shallow copies, so types with pointers, slices or maps share them between bound objects.
+type UnmarshalCache interface {
+	// Get returns value of type typ cached for input
+	Get(typ reflect.Type, input string) (interface{}, bool)
+	// Set caches value of type typ successfully unmarshalled from input
+	Set(typ reflect.Type, input string, value interface{})
+}
+
+// cachedUnmarshal sets field to value DefaultBinder.UnmarshalCache has for val, otherwise it runs unmarshal and caches
+// the result when it succeeds
+func (s *bindState) cachedUnmarshal(val string, field reflect.Value, unmarshal func() error) error {
+	cache := s.binder.UnmarshalCache
+	if cache == nil {
+		return unmarshal()
+	}
+	if v, ok := cache.Get(field.Type(), val); ok {
+		if cached := reflect.ValueOf(v); cached.IsValid() && cached.Type() == field.Type() {
+			field.Set(cached)
+			return nil
+		}
+	}
+	if err := unmarshal(); err != nil {
+		return err
+	}
+	cache.Set(field.Type(), val, field.Interface())
+	return nil
+}
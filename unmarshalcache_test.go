@@ -0,0 +1,96 @@
+package binding
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// mapCache is UnmarshalCache without eviction
+type mapCache struct {
+	mu     sync.Mutex
+	values map[reflect.Type]map[string]interface{}
+}
+
+func (c *mapCache) Get(typ reflect.Type, input string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.values[typ][input]
+	return v, ok
+}
+
+func (c *mapCache) Set(typ reflect.Type, input string, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.values == nil {
+		c.values = map[reflect.Type]map[string]interface{}{}
+	}
+	if c.values[typ] == nil {
+		c.values[typ] = map[string]interface{}{}
+	}
+	c.values[typ][input] = value
+}
+
+// lookupCalls counts calls of slugLookup.UnmarshalParam
+var lookupCalls int
+
+// slugLookup stands in for a slug resolved by an expensive lookup
+type slugLookup struct {
+	ID int
+}
+
+func (s *slugLookup) UnmarshalParam(param string) error {
+	lookupCalls++
+	if param == "bad" {
+		return errors.New("unknown slug")
+	}
+	s.ID = len(param)
+	return nil
+}
+
+func TestBindQueryParams_UnmarshalCache(t *testing.T) {
+	type query struct {
+		Product slugLookup   `query:"product"`
+		Ptr     *slugLookup  `query:"ptr"`
+		Related []slugLookup `query:"related"`
+	}
+	b := NewBinder(WithUnmarshalCache(&mapCache{}))
+	lookupCalls = 0
+	for n := 0; n < 3; n++ {
+		var q query
+		if err := b.BindQueryParams(newRequest(http.MethodGet, "/?product=shoe&ptr=shoe&related=hat&related=shoe", "", ""), &q); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := query{Product: slugLookup{4}, Ptr: &slugLookup{4}, Related: []slugLookup{{3}, {4}}}
+		if !reflect.DeepEqual(q, want) {
+			t.Errorf("got %+v, want %+v", q, want)
+		}
+	}
+	// once for shoe and hat
+	if lookupCalls != 2 {
+		t.Errorf("unmarshaler called %d times, want once per type and value", lookupCalls)
+	}
+
+	// failures are not cached
+	lookupCalls = 0
+	for n := 0; n < 2; n++ {
+		if err := b.BindQueryParams(newRequest(http.MethodGet, "/?product=bad", "", ""), &query{}); err == nil {
+			t.Error("expected unmarshaler error")
+		}
+	}
+	if lookupCalls != 2 {
+		t.Errorf("failing unmarshaler called %d times, want 2", lookupCalls)
+	}
+
+	lookupCalls = 0
+	for n := 0; n < 2; n++ {
+		if err := NewBinder().BindQueryParams(newRequest(http.MethodGet, "/?product=shoe", "", ""), &query{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if lookupCalls != 2 {
+		t.Errorf("without cache unmarshaler called %d times, want 2", lookupCalls)
+	}
+}